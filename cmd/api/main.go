@@ -1,14 +1,13 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 
 	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/api/routes"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/app"
 	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/config"
-	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/database"
-	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
-	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/services"
 	"github.com/gin-gonic/gin"
 )
 
@@ -19,52 +18,57 @@ func main() {
 		log.Fatal(err)
 	}
 
-	redisClient := database.RedisConnection(cfg)
-	if redisClient == nil {
-		log.Fatal("failed to connect to Redis")
-	}
-
-	_, err = database.ConnectDB(&cfg)
-
+	application, err := app.New(cfg)
 	if err != nil {
 		log.Fatal(err)
 	}
+	defer application.Close()
 
-	// Auto-migrate database models
-	if err := database.DB.AutoMigrate(&models.User{}, &models.JobDescription{}, &models.Resume{}, &models.CandidateScore{}, &models.Education{}, &models.Experience{}); err != nil {
+	// Auto-migrate database models and seed reference data
+	if err := application.Migrate(); err != nil {
 		log.Fatal("Failed to migrate database:", err)
 	}
 
-	// Initialize AI service
-	var aiService *services.AIService
-	if cfg.AIAPIKey != "" {
-		aiService, err = services.NewAIService(cfg.AIAPIKey)
-		if err != nil {
-			log.Printf("Warning: Failed to initialize AI service: %v", err)
-		} else {
-			log.Println("AI service initialized successfully")
-		}
-	} else {
-		log.Println("Warning: AI_API_KEY not provided, AI features will be disabled")
+	// Load the skill dictionary and follow changes made by other instances
+	if err := application.Start(context.Background()); err != nil {
+		log.Fatal("Failed to load skill dictionary:", err)
 	}
 
+	// Run match runs in this process unless a separate worker handles them
+	for i := 0; i < cfg.MatchWorkers; i++ {
+		go application.MatchRuns.Work(context.Background(), fmt.Sprintf("api-%d", i+1))
+	}
+
+	aiService := application.AIService
 	port := cfg.Port
 
 	r := gin.Default()
 
-	// Set config and AI service in context for use in controllers
+	// Set config and services in context for use in controllers
 	r.Use(func(c *gin.Context) {
 		c.Set("config", cfg)
 		c.Set("aiService", aiService)
+		c.Set("skillDictionary", application.SkillDictionary)
+		c.Set("scoringProfiles", application.ScoringProfiles)
+		c.Set("matchRuns", application.MatchRuns)
+		c.Set("jobRecommendations", application.Recommendations)
+		c.Set("auth", application.Auth)
+		c.Set("audit", application.Audit)
+		c.Set("organizations", application.Organizations)
+		c.Set("aiRegistry", application.AIRegistry)
 		c.Next()
 	})
 
 	r.GET("/", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"message": "AI Resume Screener API",
-			"version": "1.0.0",
+		response := gin.H{
+			"message":    "AI Resume Screener API",
+			"version":    "1.0.0",
 			"ai_enabled": aiService != nil,
-		})
+		}
+		if aiService != nil {
+			response["ai_breakers"] = aiService.BreakerStates()
+		}
+		c.JSON(200, response)
 	})
 
 	routes.SetUpRoutes(r)
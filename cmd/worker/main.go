@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/app"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/config"
+)
+
+// The worker executes queued match runs. Run it alongside an API started
+// with MATCH_WORKERS=0; the API migrates the database on startup. It also
+// deletes resumes past their organization's retention period.
+func main() {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	application, err := app.New(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer application.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := application.Start(ctx); err != nil {
+		log.Fatal("Failed to load skill dictionary:", err)
+	}
+
+	if cfg.RetentionInterval > 0 {
+		go application.Organizations.RunRetention(ctx, cfg.RetentionInterval)
+	}
+
+	workers := cfg.MatchWorkers
+	if workers <= 0 {
+		workers = 1
+	}
+
+	hostname, _ := os.Hostname()
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			application.MatchRuns.Work(ctx, id)
+		}(fmt.Sprintf("%s-%d", hostname, i+1))
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,99 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/database"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/services"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func aiCacheFromContext(c *gin.Context) *services.AICache {
+	aiService := aiServiceFromContext(c)
+	if aiService == nil {
+		return nil
+	}
+	return aiService.Cache()
+}
+
+func GetAICacheStats(c *gin.Context) {
+	cache := aiCacheFromContext(c)
+	if cache == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "AI cache is not enabled"})
+		return
+	}
+
+	stats, err := cache.Stats(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read cache stats"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"stats": stats,
+	})
+}
+
+// PurgeAICache drops cached AI answers for the job and/or resume given in
+// the job_id and resume_id query parameters.
+func PurgeAICache(c *gin.Context) {
+	cache := aiCacheFromContext(c)
+	if cache == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "AI cache is not enabled"})
+		return
+	}
+
+	jobID := c.Query("job_id")
+	resumeID := c.Query("resume_id")
+	if jobID == "" && resumeID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "job_id or resume_id is required"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	removed := int64(0)
+
+	if jobID != "" {
+		var job models.JobDescription
+		if err := database.DB.Where("id = ?", jobID).First(&job).Error; err != nil {
+			respondLookupError(c, err, "Job not found")
+			return
+		}
+		n, err := cache.PurgeJob(ctx, &job)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to purge cache"})
+			return
+		}
+		removed += n
+	}
+
+	if resumeID != "" {
+		var resume models.Resume
+		if err := database.DB.Where("id = ?", resumeID).First(&resume).Error; err != nil {
+			respondLookupError(c, err, "Resume not found")
+			return
+		}
+		n, err := cache.PurgeResume(ctx, &resume)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to purge cache"})
+			return
+		}
+		removed += n
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Cache purged successfully",
+		"removed": removed,
+	})
+}
+
+func respondLookupError(c *gin.Context, err error, notFound string) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": notFound})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
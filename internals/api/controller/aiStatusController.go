@@ -0,0 +1,23 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetAIStatus reports the circuit breaker state of each AI provider.
+func GetAIStatus(c *gin.Context) {
+	aiService := aiServiceFromContext(c)
+	if aiService == nil {
+		c.JSON(http.StatusOK, gin.H{
+			"ai_enabled": false,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"ai_enabled": true,
+		"breakers":   aiService.BreakerStates(),
+	})
+}
@@ -0,0 +1,23 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListAuditEvents lists recent audit events, newest first. Filters: type,
+// user_id and limit (default 50, max 500).
+func ListAuditEvents(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	events, err := auditFromContext(c).List(c.Query("type"), c.Query("user_id"), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch audit events"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"events": events,
+	})
+}
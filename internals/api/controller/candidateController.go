@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/services"
+	"github.com/gin-gonic/gin"
+)
+
+// ListCandidates shortlists a job's candidates with filters, sorting and
+// cursor pagination. Skills can be repeated or comma separated.
+func ListCandidates(c *gin.Context) {
+	var filter services.CandidateFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filter.Skills = splitList(filter.Skills)
+	// Viewers may see scores but not the resumes behind them.
+	if !services.HasPermission(c.GetString("role"), services.PermResumesRead) {
+		filter.IncludeParsedText = false
+	}
+
+	if value := c.Query("created_after"); value != "" {
+		createdAfter, err := services.ParseCandidateTime(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		filter.CreatedAfter = &createdAfter
+	}
+
+	var job models.JobDescription
+	if err := tenantDB(c).Select("id").Where("id = ?", c.Param("jobId")).First(&job).Error; err != nil {
+		respondLookupError(c, err, "Job not found")
+		return
+	}
+
+	page, err := services.SearchCandidates(tenantDB(c), job.ID, filter)
+	if errors.Is(err, services.ErrInvalidCandidateFilter) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch candidates"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"candidates":  page.Candidates,
+		"next_cursor": page.NextCursor,
+	})
+}
+
+// splitList flattens query values that may be repeated or comma separated.
+func splitList(values []string) []string {
+	var items []string
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+	return items
+}
@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/config"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/database"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/services"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// aiServiceFromContext returns the AI service set by main, or nil when AI is
+// disabled. main stores a typed nil pointer in that case, so the value has
+// to be unwrapped before it can be compared against nil.
+func aiServiceFromContext(c *gin.Context) *services.AIService {
+	svc, _ := c.Get("aiService")
+	aiService, _ := svc.(*services.AIService)
+	return aiService
+}
+
+// organizationAIService returns the AI service the caller's organization
+// uses, or nil when it works without AI.
+func organizationAIService(c *gin.Context) *services.AIService {
+	svc, _ := c.Get("aiRegistry")
+	registry, ok := svc.(*services.AIRegistry)
+	if !ok {
+		return aiServiceFromContext(c)
+	}
+	organizationID := c.GetString("organization_id")
+	if organizationID == "" {
+		return registry.For(nil)
+	}
+	return registry.For(&organizationID)
+}
+
+func skillDictionaryFromContext(c *gin.Context) *services.SkillDictionaryService {
+	svc, _ := c.Get("skillDictionary")
+	dictionary, _ := svc.(*services.SkillDictionaryService)
+	return dictionary
+}
+
+func matchRunsFromContext(c *gin.Context) *services.MatchRunService {
+	svc, _ := c.Get("matchRuns")
+	matchRuns, _ := svc.(*services.MatchRunService)
+	return matchRuns
+}
+
+func scoringProfilesFromContext(c *gin.Context) *services.ScoringProfileService {
+	svc, _ := c.Get("scoringProfiles")
+	profiles, _ := svc.(*services.ScoringProfileService)
+	return profiles
+}
+
+func configFromContext(c *gin.Context) config.Config {
+	value, _ := c.Get("config")
+	cfg, _ := value.(config.Config)
+	return cfg
+}
+
+func jobRecommendationsFromContext(c *gin.Context) *services.JobRecommendationService {
+	svc, _ := c.Get("jobRecommendations")
+	recommendations, _ := svc.(*services.JobRecommendationService)
+	return recommendations
+}
+
+func authFromContext(c *gin.Context) *services.AuthService {
+	svc, _ := c.Get("auth")
+	auth, _ := svc.(*services.AuthService)
+	return auth
+}
+
+func auditFromContext(c *gin.Context) *services.AuditService {
+	svc, _ := c.Get("audit")
+	audit, _ := svc.(*services.AuditService)
+	return audit
+}
+
+func organizationsFromContext(c *gin.Context) *services.OrganizationService {
+	svc, _ := c.Get("organizations")
+	organizations, _ := svc.(*services.OrganizationService)
+	return organizations
+}
+
+// tenantDB returns the database handle for the request. AuthMiddleware puts
+// the caller's organization in the request context, so models that belong
+// to an organization are filtered by it.
+func tenantDB(c *gin.Context) *gorm.DB {
+	return database.DB.WithContext(c.Request.Context())
+}
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"log"
 	"net/http"
 	"strconv"
 
@@ -8,10 +9,9 @@ import (
 	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/services"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
-var jobMatcher = services.NewJobMatcherService(nil) // Will be updated with AI service
-
 func CreateJob(c *gin.Context) {
 	var job models.JobDescription
 
@@ -21,22 +21,268 @@ func CreateJob(c *gin.Context) {
 	}
 
 	job.ID = uuid.New().String()
+	job.OrganizationID = nil // Set from the caller's organization on create
+	job.CreatedBy = nil
+	if userID := c.GetString("user_id"); userID != "" {
+		job.CreatedBy = &userID
+	}
+	if job.Status == "" {
+		job.Status = models.JobStatusOpen
+	}
+	if !validateJob(c, &job) {
+		return
+	}
 
-	if err := models.DB.Create(&job).Error; err != nil {
+	if err := tenantDB(c).Create(&job).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create job"})
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{
+	jobRecommendationsFromContext(c).InvalidateJobs(c.Request.Context())
+
+	response := gin.H{
 		"message": "Job created successfully",
 		"job":     job,
+	}
+	if runID := queueAutoMatch(c, &job); runID != "" {
+		response["auto_match_run_id"] = runID
+	}
+	c.JSON(http.StatusCreated, response)
+}
+
+// GetJob returns one job with its scoring profile.
+func GetJob(c *gin.Context) {
+	var job models.JobDescription
+	if err := tenantDB(c).Preload("ScoringProfile").Where("id = ?", c.Param("jobId")).First(&job).Error; err != nil {
+		respondLookupError(c, err, "Job not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"job": job,
 	})
 }
 
+// UpdateJob replaces a job's fields. An empty status keeps the current one.
+func UpdateJob(c *gin.Context) {
+	var job models.JobDescription
+	if err := tenantDB(c).Where("id = ?", c.Param("jobId")).First(&job).Error; err != nil {
+		respondLookupError(c, err, "Job not found")
+		return
+	}
+	before := job
+
+	var input models.JobDescription
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	job.Title = input.Title
+	job.Description = input.Description
+	job.RequiredSkills = input.RequiredSkills
+	job.NiceToHaveSkills = input.NiceToHaveSkills
+	job.ExperienceLevel = input.ExperienceLevel
+	job.MinExperience = input.MinExperience
+	job.EducationRequired = input.EducationRequired
+	job.Location = input.Location
+	job.SalaryRange = input.SalaryRange
+	job.ScoringProfileID = input.ScoringProfileID
+	job.KnockoutRules = input.KnockoutRules
+	job.AutoMatch = input.AutoMatch
+	if input.Status != "" {
+		job.Status = input.Status
+	}
+
+	saveJobChanges(c, &before, &job)
+}
+
+// jobPatch holds the fields PatchJob may change. Absent fields are left
+// alone. The scoring profile is changed through AttachScoringProfile.
+type jobPatch struct {
+	Title             *string                `json:"title"`
+	Description       *string                `json:"description"`
+	RequiredSkills    *[]string              `json:"required_skills"`
+	NiceToHaveSkills  *[]string              `json:"nice_to_have_skills"`
+	ExperienceLevel   *string                `json:"experience_level"`
+	MinExperience     *int                   `json:"min_experience"`
+	EducationRequired *string                `json:"education_required"`
+	Location          *string                `json:"location"`
+	SalaryRange       *string                `json:"salary_range"`
+	KnockoutRules     *[]models.KnockoutRule `json:"knockout_rules"`
+	AutoMatch         *bool                  `json:"auto_match"`
+	Status            *string                `json:"status"`
+}
+
+// PatchJob changes only the fields present in the body, e.g.
+// {"status": "closed"} to close a filled position.
+func PatchJob(c *gin.Context) {
+	var job models.JobDescription
+	if err := tenantDB(c).Where("id = ?", c.Param("jobId")).First(&job).Error; err != nil {
+		respondLookupError(c, err, "Job not found")
+		return
+	}
+	before := job
+
+	var patch jobPatch
+	if err := c.ShouldBindJSON(&patch); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	setIfPresent(&job.Title, patch.Title)
+	setIfPresent(&job.Description, patch.Description)
+	setIfPresent(&job.RequiredSkills, patch.RequiredSkills)
+	setIfPresent(&job.NiceToHaveSkills, patch.NiceToHaveSkills)
+	setIfPresent(&job.ExperienceLevel, patch.ExperienceLevel)
+	setIfPresent(&job.MinExperience, patch.MinExperience)
+	setIfPresent(&job.EducationRequired, patch.EducationRequired)
+	setIfPresent(&job.Location, patch.Location)
+	setIfPresent(&job.SalaryRange, patch.SalaryRange)
+	setIfPresent(&job.KnockoutRules, patch.KnockoutRules)
+	setIfPresent(&job.AutoMatch, patch.AutoMatch)
+	setIfPresent(&job.Status, patch.Status)
+
+	saveJobChanges(c, &before, &job)
+}
+
+func setIfPresent[T any](field *T, value *T) {
+	if value != nil {
+		*field = *value
+	}
+}
+
+// saveJobChanges validates and saves an edited job. Changing the
+// requirements marks the job's scores as stale. With auto_match set, an
+// open job is re-matched when its requirements change, when it is opened or
+// when auto_match is switched on.
+func saveJobChanges(c *gin.Context, before, job *models.JobDescription) {
+	if !validateJob(c, job) {
+		return
+	}
+	if err := services.ValidateJobStatusTransition(before.Status, job.Status); err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if job.CreatedBy == nil {
+		if userID := c.GetString("user_id"); userID != "" {
+			job.CreatedBy = &userID
+		}
+	}
+
+	requirementsChanged := services.JobRequirementsChanged(before, job)
+	err := tenantDB(c).Transaction(func(tx *gorm.DB) error {
+		// Select the columns so that cleared fields are written too.
+		err := tx.Model(job).Select(
+			"title", "description", "required_skills", "nice_to_have_skills",
+			"experience_level", "min_experience", "education_required", "location",
+			"salary_range", "scoring_profile_id", "knockout_rules", "auto_match",
+			"created_by", "status",
+		).Updates(job).Error
+		if err != nil || !requirementsChanged {
+			return err
+		}
+		return services.MarkScoresStale(tx, job.ID)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update job"})
+		return
+	}
+
+	jobRecommendationsFromContext(c).InvalidateJobs(c.Request.Context())
+
+	response := gin.H{
+		"message": "Job updated successfully",
+		"job":     job,
+	}
+	rematch := requirementsChanged || !before.AutoMatch || before.Status != models.JobStatusOpen ||
+		!equalStringPtr(before.ScoringProfileID, job.ScoringProfileID)
+	if rematch {
+		if runID := queueAutoMatch(c, job); runID != "" {
+			response["auto_match_run_id"] = runID
+		}
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func equalStringPtr(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// DeleteJob soft-deletes a job. Its scores are kept but the job no longer
+// appears in listings or recommendations and cannot be matched.
+func DeleteJob(c *gin.Context) {
+	result := tenantDB(c).Where("id = ?", c.Param("jobId")).Delete(&models.JobDescription{})
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete job"})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		return
+	}
+
+	jobRecommendationsFromContext(c).InvalidateJobs(c.Request.Context())
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Job deleted successfully",
+	})
+}
+
+// validateJob checks the required fields, status, knockout rules and
+// scoring profile, writing the error response when they are invalid.
+func validateJob(c *gin.Context, job *models.JobDescription) bool {
+	job.ScoringProfile = nil
+	if err := services.ValidateJob(job); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return false
+	}
+	if err := services.ValidateKnockoutRules(job.KnockoutRules); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return false
+	}
+	if job.ScoringProfileID != nil {
+		if _, err := scoringProfilesFromContext(c).Get(c.Request.Context(), *job.ScoringProfileID); err != nil {
+			respondScoringProfileError(c, err)
+			return false
+		}
+	}
+	return true
+}
+
+// queueAutoMatch queues a background match for open jobs with auto_match
+// set and returns the run ID. Failing to queue is logged rather than
+// failing the request, since the job itself was saved.
+func queueAutoMatch(c *gin.Context, job *models.JobDescription) string {
+	if !job.AutoMatch || job.Status != models.JobStatusOpen {
+		return ""
+	}
+	run, err := matchRunsFromContext(c).EnqueueAutoMatch(job, configFromContext(c).AutoMatchTopN)
+	if err != nil {
+		log.Printf("Failed to queue auto-match for job %s: %v", job.ID, err)
+		return ""
+	}
+	return run.ID
+}
+
+// GetJobs lists jobs, optionally filtered by status, e.g. ?status=open,paused.
 func GetJobs(c *gin.Context) {
-	var jobs []models.JobDescription
+	statuses, err := services.ParseJobStatuses(c.Query("status"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
-	if err := models.DB.Find(&jobs).Error; err != nil {
+	query := tenantDB(c).Order("created_at DESC")
+	if len(statuses) > 0 {
+		query = query.Where("status IN ?", statuses)
+	}
+
+	var jobs []models.JobDescription
+	if err := query.Find(&jobs).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch jobs"})
 		return
 	}
@@ -46,46 +292,63 @@ func GetJobs(c *gin.Context) {
 	})
 }
 
+// MatchCandidates queues a run that scores every resume against the job and
+// returns it for polling. The optional mode query parameter picks heuristic,
+// ai or hybrid scoring; it defaults to hybrid when AI is enabled for the
+// job's organization. Only new or changed resumes are scored unless
+// full=true.
 func MatchCandidates(c *gin.Context) {
 	jobID := c.Param("jobId")
 
 	var job models.JobDescription
-	if err := models.DB.Where("id = ?", jobID).First(&job).Error; err != nil {
+	if err := tenantDB(c).Where("id = ?", jobID).First(&job).Error; err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
 		return
 	}
 
-	// Get all resumes
-	var resumes []models.Resume
-	if err := models.DB.Find(&resumes).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch resumes"})
+	matchRuns := matchRunsFromContext(c)
+	jobMatcher := matchRuns.Matcher()
+	mode, err := jobMatcher.ParseScoringMode(c.Query("mode"), &job)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-
-	var scores []models.CandidateScore
-	for _, resume := range resumes {
-		score := jobMatcher.MatchResumeToJob(&resume, &job)
-		scores = append(scores, *score)
+	if mode != services.ScoringModeHeuristic && !jobMatcher.AIEnabled(&job) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "AI scoring is not enabled"})
+		return
+	}
+	if !services.JobMatchable(&job) {
+		c.JSON(http.StatusConflict, gin.H{"error": services.ErrJobNotMatchable.Error()})
+		return
 	}
 
-	// Save scores to DB
-	for _, score := range scores {
-		models.DB.Create(&score)
+	run, err := matchRuns.Enqueue(&job, mode, c.Query("full") == "true")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to queue match run"})
+		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"message": "Candidates matched successfully",
-		"scores":  scores,
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "Match run queued",
+		"run_id":  run.ID,
+		"run":     run,
 	})
 }
 
+// GetTopCandidates lists the best scores for a job. Disqualified candidates
+// are left out unless include_disqualified=true.
 func GetTopCandidates(c *gin.Context) {
 	jobID := c.Param("jobId")
 	limitStr := c.DefaultQuery("limit", "10")
 	limit, _ := strconv.Atoi(limitStr)
 
+	query := tenantDB(c).Where("job_id = ?", jobID)
+	if c.Query("include_disqualified") != "true" {
+		query = query.Where("disqualified = ?", false)
+	}
+
 	var scores []models.CandidateScore
-	if err := models.DB.Where("job_id = ?", jobID).Order("score DESC").Limit(limit).Find(&scores).Error; err != nil {
+	if err := query.Order("score DESC").Limit(limit).Find(&scores).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch candidates"})
 		return
 	}
@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/services"
+	"github.com/gin-gonic/gin"
+)
+
+func respondMatchRunError(c *gin.Context, err error) {
+	if errors.Is(err, services.ErrMatchRunNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
+// GetMatchRun reports a run's status and progress.
+func GetMatchRun(c *gin.Context) {
+	run, err := matchRunsFromContext(c).Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondMatchRunError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"run": run,
+	})
+}
+
+// CancelMatchRun cancels a queued run or asks a running one to stop after
+// its current batch.
+func CancelMatchRun(c *gin.Context) {
+	run, err := matchRunsFromContext(c).Cancel(c.Request.Context(), c.Param("id"))
+	if errors.Is(err, services.ErrMatchRunFinished) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "run": run})
+		return
+	}
+	if err != nil {
+		respondMatchRunError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Match run cancellation requested",
+		"run":     run,
+	})
+}
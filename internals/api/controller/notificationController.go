@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/database"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// GetNotifications lists the caller's notifications, newest first.
+// unread=true hides notifications that were already read.
+func GetNotifications(c *gin.Context) {
+	query := notificationsFor(c)
+	if c.Query("unread") == "true" {
+		query = query.Where("read_at IS NULL")
+	}
+
+	var notifications []models.Notification
+	if err := query.Order("created_at DESC").Find(&notifications).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch notifications"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"notifications": notifications,
+	})
+}
+
+// MarkNotificationRead marks one of the caller's notifications as read.
+func MarkNotificationRead(c *gin.Context) {
+	var notification models.Notification
+	if err := notificationsFor(c).Where("id = ?", c.Param("id")).First(&notification).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Notification not found"})
+		return
+	}
+
+	if notification.ReadAt == nil {
+		now := time.Now()
+		notification.ReadAt = &now
+		if err := database.DB.Model(&notification).Update("read_at", now).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update notification"})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"notification": notification,
+	})
+}
+
+// notificationsFor returns the notifications addressed to the caller.
+// Notifications stored without a recipient, from jobs created before
+// sign-in was required, go to the owner of the job. They list candidate
+// names and emails, so nobody else may see them.
+func notificationsFor(c *gin.Context) *gorm.DB {
+	userID := c.GetString("user_id")
+	ownedJobs := database.DB.Model(&models.JobDescription{}).Select("id").Where("created_by = ?", userID)
+	return database.DB.Model(&models.Notification{}).
+		Where("user_id = ? OR (user_id IS NULL AND job_id IN (?))", userID, ownedJobs)
+}
@@ -0,0 +1,225 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/database"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/services"
+	"github.com/gin-gonic/gin"
+)
+
+func respondOrganizationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrOrganizationNotFound), errors.Is(err, services.ErrNotOrganizationMember),
+		errors.Is(err, services.ErrInviteNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidOrganization), errors.Is(err, services.ErrInvalidRole):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrLastOrganizationAdmin), errors.Is(err, services.ErrAlreadyMember):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInviteInvalid):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update organization", "message": err.Error()})
+	}
+}
+
+type createOrganizationInput struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// CreateOrganization creates an organization with the caller, a platform
+// admin, as its admin.
+// The caller switches to it with POST /user/switch-organization.
+func CreateOrganization(c *gin.Context) {
+	var input createOrganizationInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	organization, err := organizationsFromContext(c).Create(input.Name, c.GetString("user_id"))
+	if err != nil {
+		respondOrganizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":      "Organization created",
+		"organization": organization,
+	})
+}
+
+// ListMyOrganizations lists the organizations the caller belongs to.
+func ListMyOrganizations(c *gin.Context) {
+	memberships, err := organizationsFromContext(c).ListForUser(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch organizations"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"organizations": memberships,
+		"current":       c.GetString("organization_id"),
+	})
+}
+
+// GetOrganization returns the caller's current organization.
+func GetOrganization(c *gin.Context) {
+	organization, err := organizationsFromContext(c).Get(c.GetString("organization_id"))
+	if err != nil {
+		respondOrganizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"organization": organization,
+		"role":         c.GetString("role"),
+	})
+}
+
+// UpdateOrganizationSettings replaces the current organization's settings.
+func UpdateOrganizationSettings(c *gin.Context) {
+	var settings models.OrganizationSettings
+	if err := c.ShouldBindJSON(&settings); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	organization, err := organizationsFromContext(c).UpdateSettings(c.GetString("organization_id"), settings)
+	if err != nil {
+		respondOrganizationError(c, err)
+		return
+	}
+	jobRecommendationsFromContext(c).InvalidateJobs(c.Request.Context())
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":      "Settings updated",
+		"organization": organization,
+	})
+}
+
+func ListOrganizationMembers(c *gin.Context) {
+	members, err := organizationsFromContext(c).Members(c.GetString("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch members"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"members": members})
+}
+
+// SetOrganizationMemberRole changes a member's role in the current
+// organization. The member has to log in again to use it.
+func SetOrganizationMemberRole(c *gin.Context) {
+	var input roleInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	member, err := organizationsFromContext(c).SetMemberRole(c.GetString("organization_id"), c.Param("userId"), input.Role)
+	if err != nil {
+		respondOrganizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Role updated",
+		"member":  member,
+	})
+}
+
+// RemoveOrganizationMember takes a user out of the current organization and
+// ends their session.
+func RemoveOrganizationMember(c *gin.Context) {
+	if err := organizationsFromContext(c).RemoveMember(c.GetString("organization_id"), c.Param("userId")); err != nil {
+		respondOrganizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Member removed"})
+}
+
+type inviteInput struct {
+	Email string `json:"email" binding:"required"`
+	Role  string `json:"role"` // Defaults to viewer
+}
+
+// CreateOrganizationInvite invites an email address to the current
+// organization. The token in the response is only shown once and has to be
+// passed on to the invitee.
+func CreateOrganizationInvite(c *gin.Context) {
+	var input inviteInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if input.Role == "" {
+		input.Role = models.RoleViewer
+	}
+
+	invite, token, err := organizationsFromContext(c).CreateInvite(c.GetString("organization_id"), input.Email, input.Role, c.GetString("user_id"))
+	if err != nil {
+		respondOrganizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Invite created",
+		"invite":  invite,
+		"token":   token,
+	})
+}
+
+func ListOrganizationInvites(c *gin.Context) {
+	invites, err := organizationsFromContext(c).PendingInvites(c.GetString("organization_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch invites"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"invites": invites})
+}
+
+func RevokeOrganizationInvite(c *gin.Context) {
+	if err := organizationsFromContext(c).RevokeInvite(c.GetString("organization_id"), c.Param("id")); err != nil {
+		respondOrganizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Invite revoked"})
+}
+
+type acceptInviteInput struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// AcceptOrganizationInvite adds the caller to the organization the invite
+// is for. The invite must have been sent to the caller's email.
+func AcceptOrganizationInvite(c *gin.Context) {
+	var input acceptInviteInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var user models.User
+	if err := database.DB.Where("id = ?", c.GetString("user_id")).First(&user).Error; err != nil {
+		respondLookupError(c, err, "User not found")
+		return
+	}
+
+	member, err := organizationsFromContext(c).AcceptInvite(input.Token, &user)
+	if err != nil {
+		respondOrganizationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Invite accepted",
+		"member":  member,
+	})
+}
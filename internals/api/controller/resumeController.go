@@ -1,11 +1,18 @@
 package controller
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"net/mail"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
 	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/services"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/taxonomy"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
@@ -13,84 +20,331 @@ import (
 var resumeParser = services.NewResumeParserService()
 
 func UploadResume(c *gin.Context) {
+	fileID := uuid.New().String()
+	parsedData, ok := saveAndParseResume(c, fileID)
+	if !ok {
+		return
+	}
+
+	// Save resume data to DB
+	resume := models.Resume{
+		ID:             fileID,
+		CandidateName:  parsedData.CandidateName,
+		Email:          parsedData.Email,
+		Phone:          parsedData.Phone,
+		Location:       parsedData.Location,
+		Education:      parsedData.Education,
+		Experience:     parsedData.Experience,
+		Skills:         parsedData.Skills,
+		Certifications: parsedData.Certifications,
+		FilePath:       parsedData.FilePath,
+		ParsedText:     parsedData.ParsedText,
+	}
+
+	if err := tenantDB(c).Create(&resume).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save resume data"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Resume uploaded and parsed successfully",
+		"resume":  resume,
+	})
+}
+
+// ReplaceResume re-uploads the file of an existing resume, for example a
+// candidate's updated CV. Every parsed field is replaced, including any
+// corrections made with PATCH, and the resume is re-scored by the next
+// match runs. The old file is removed once the new one is saved.
+func ReplaceResume(c *gin.Context) {
+	var resume models.Resume
+	if err := tenantDB(c).Where("id = ?", c.Param("id")).First(&resume).Error; err != nil {
+		respondLookupError(c, err, "Resume not found")
+		return
+	}
+	oldFilePath := resume.FilePath
+
+	parsedData, ok := saveAndParseResume(c, uuid.New().String())
+	if !ok {
+		return
+	}
+
+	resume.CandidateName = parsedData.CandidateName
+	resume.Email = parsedData.Email
+	resume.Phone = parsedData.Phone
+	resume.Location = parsedData.Location
+	resume.Skills = parsedData.Skills
+	resume.Certifications = parsedData.Certifications
+	resume.FilePath = parsedData.FilePath
+	resume.ParsedText = parsedData.ParsedText
+
+	changes := services.ResumeChanges{
+		Columns: []string{
+			"candidate_name", "email", "phone", "location", "skills",
+			"certifications", "file_path", "parsed_text",
+		},
+		Education:  &parsedData.Education,
+		Experience: &parsedData.Experience,
+	}
+	if err := services.UpdateResume(tenantDB(c), &resume, changes); err != nil {
+		services.RemoveResumeFile(parsedData.FilePath)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update resume"})
+		return
+	}
+	if oldFilePath != parsedData.FilePath {
+		services.RemoveResumeFile(oldFilePath)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Resume replaced and parsed successfully",
+		"resume":  resume,
+	})
+}
+
+// saveAndParseResume validates the uploaded "resume" file, saves it as
+// uploads/<fileID><ext> and parses it, writing the error response when
+// any step fails. The returned resume has FilePath set but is not saved.
+func saveAndParseResume(c *gin.Context, fileID string) (*models.Resume, bool) {
 	file, err := c.FormFile("resume")
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Resume file is required"})
-		return
+		return nil, false
 	}
 
 	// Validate file type
-	ext := filepath.Ext(file.Filename)
+	ext := strings.ToLower(filepath.Ext(file.Filename))
 	if ext != ".pdf" && ext != ".docx" && ext != ".txt" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid file type. Only PDF, DOCX, and TXT files are allowed"})
-		return
+		return nil, false
 	}
 
 	// Validate file size (max 10MB)
 	if file.Size > 10*1024*1024 {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "File size too large. Maximum size is 10MB"})
-		return
+		return nil, false
 	}
 
 	// Save uploaded file to disk
-	fileID := uuid.New().String()
 	filePath := "uploads/" + fileID + ext
 
 	if err := c.SaveUploadedFile(file, filePath); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file"})
-		return
+		return nil, false
 	}
 
 	// Parse resume file
-	parsedData, err := resumeParser.ParseResume(filePath)
+	src, err := file.Open()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to parse resume"})
+		services.RemoveResumeFile(filePath)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read file"})
+		return nil, false
+	}
+	defer src.Close()
+
+	parsedData, err := resumeParser.ParseResume(src, file)
+	if err != nil {
+		services.RemoveResumeFile(filePath)
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "Failed to parse resume", "message": err.Error()})
+		return nil, false
+	}
+
+	// Use the organization's AI service for enhanced skill extraction
+	if aiSvc := organizationAIService(c); aiSvc != nil {
+		if aiExtraction, err := aiSvc.ExtractSkillsFromText(c.Request.Context(), parsedData.ParsedText); err == nil {
+			// Merge AI-extracted skills with parsed skills under their canonical names
+			parsedData.Skills = taxonomy.Default().Normalize(append(parsedData.Skills, aiExtraction.Skills...))
+		}
+	}
+
+	parsedData.FilePath = filePath
+	return parsedData, true
+}
+
+// GetRecommendedJobs ranks the jobs that fit a resume best, scored with each
+// job's own weights and knockout rules.
+func GetRecommendedJobs(c *gin.Context) {
+	var resume models.Resume
+	if err := tenantDB(c).Preload("Education").Preload("Experience").Where("id = ?", c.Param("id")).First(&resume).Error; err != nil {
+		respondLookupError(c, err, "Resume not found")
 		return
 	}
 
-	// Get AI service from context for enhanced skill extraction
-	aiService, exists := c.Get("aiService")
-	if exists && aiService != nil {
-		aiSvc := aiService.(*services.AIService)
-		if aiExtraction, err := aiSvc.ExtractSkillsFromText(parsedData.RawText); err == nil {
-			// Merge AI-extracted skills with parsed skills
-			skillMap := make(map[string]bool)
-			for _, skill := range parsedData.Skills {
-				skillMap[strings.ToLower(skill)] = true
-			}
-			for _, skill := range aiExtraction.Skills {
-				skillMap[strings.ToLower(skill)] = true
-			}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	recommendations, err := jobRecommendationsFromContext(c).Recommend(c.Request.Context(), &resume, limit, c.Query("include_disqualified") == "true")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to recommend jobs"})
+		return
+	}
 
-			var mergedSkills []string
-			for skill := range skillMap {
-				mergedSkills = append(mergedSkills, strings.Title(skill))
+	c.JSON(http.StatusOK, gin.H{
+		"resume_id": resume.ID,
+		"jobs":      recommendations,
+	})
+}
+
+// ListResumes lists resumes newest first with cursor pagination. Filters:
+// skills (repeated or comma separated, skills_mode=all|any), name, email,
+// uploaded_after and uploaded_before. The parsed text is left out.
+func ListResumes(c *gin.Context) {
+	var filter services.ResumeFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filter.Skills = splitList(filter.Skills)
+
+	var ok bool
+	if filter.UploadedAfter, ok = timeQuery(c, "uploaded_after"); !ok {
+		return
+	}
+	if filter.UploadedBefore, ok = timeQuery(c, "uploaded_before"); !ok {
+		return
+	}
+
+	page, err := services.SearchResumes(tenantDB(c), filter)
+	if errors.Is(err, services.ErrInvalidResumeFilter) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch resumes"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"resumes":     page.Resumes,
+		"next_cursor": page.NextCursor,
+	})
+}
+
+// timeQuery parses an optional date query parameter, writing the error
+// response when it is malformed.
+func timeQuery(c *gin.Context, param string) (*time.Time, bool) {
+	value := c.Query(param)
+	if value == "" {
+		return nil, true
+	}
+	t, err := services.ParseCandidateTime(value)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: %q is not a date", param, value)})
+		return nil, false
+	}
+	return &t, true
+}
+
+// GetResume returns one resume with its education and experience.
+func GetResume(c *gin.Context) {
+	var resume models.Resume
+	if err := tenantDB(c).Preload("Education").Preload("Experience").Where("id = ?", c.Param("id")).First(&resume).Error; err != nil {
+		respondLookupError(c, err, "Resume not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"resume": resume,
+	})
+}
+
+// resumePatch holds the fields recruiters may correct. Absent fields are
+// left alone; education and experience replace the stored entries.
+type resumePatch struct {
+	CandidateName  *string              `json:"candidate_name"`
+	Email          *string              `json:"email"`
+	Phone          *string              `json:"phone"`
+	Location       *string              `json:"location"`
+	Skills         *[]string            `json:"skills"`
+	Certifications *[]string            `json:"certifications"`
+	Education      *[]models.Education  `json:"education"`
+	Experience     *[]models.Experience `json:"experience"`
+}
+
+// PatchResume fixes mis-parsed fields. The resume is re-scored by the next
+// match runs.
+func PatchResume(c *gin.Context) {
+	var resume models.Resume
+	if err := tenantDB(c).Preload("Education").Preload("Experience").Where("id = ?", c.Param("id")).First(&resume).Error; err != nil {
+		respondLookupError(c, err, "Resume not found")
+		return
+	}
+
+	var patch resumePatch
+	if err := c.ShouldBindJSON(&patch); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	changes := services.ResumeChanges{Education: patch.Education, Experience: patch.Experience}
+	if patch.CandidateName != nil {
+		name := strings.TrimSpace(*patch.CandidateName)
+		if name == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "candidate_name cannot be empty"})
+			return
+		}
+		resume.CandidateName = name
+		changes.Columns = append(changes.Columns, "candidate_name")
+	}
+	if patch.Email != nil {
+		address, err := mail.ParseAddress(strings.TrimSpace(*patch.Email))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid email address"})
+			return
+		}
+		resume.Email = address.Address
+		changes.Columns = append(changes.Columns, "email")
+	}
+	if patch.Phone != nil {
+		resume.Phone = strings.TrimSpace(*patch.Phone)
+		changes.Columns = append(changes.Columns, "phone")
+	}
+	if patch.Location != nil {
+		location := strings.TrimSpace(*patch.Location)
+		resume.Location = &location
+		changes.Columns = append(changes.Columns, "location")
+	}
+	if patch.Skills != nil {
+		resume.Skills = taxonomy.Default().Normalize(*patch.Skills)
+		changes.Columns = append(changes.Columns, "skills")
+	}
+	if patch.Certifications != nil {
+		resume.Certifications = *patch.Certifications
+		changes.Columns = append(changes.Columns, "certifications")
+	}
+	if patch.Education != nil {
+		for i, education := range *patch.Education {
+			if strings.TrimSpace(education.Degree) == "" {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "education " + strconv.Itoa(i) + ": degree is required"})
+				return
 			}
-			parsedData.Skills = mergedSkills
 		}
 	}
-
-	// Save resume data to DB
-	resume := models.Resume{
-		ID:            fileID,
-		CandidateName: parsedData.CandidateName,
-		Email:         parsedData.Email,
-		Phone:         parsedData.Phone,
-		Education:     parsedData.Education,
-		Experience:    parsedData.Experience,
-		Skills:        parsedData.Skills,
-		Certifications: parsedData.Certifications,
-		FilePath:      filePath,
-		ParsedText:    parsedData.RawText,
+	if patch.Experience != nil {
+		for i, experience := range *patch.Experience {
+			if strings.TrimSpace(experience.Company) == "" && strings.TrimSpace(experience.Role) == "" {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "experience " + strconv.Itoa(i) + ": company or role is required"})
+				return
+			}
+		}
 	}
 
-	if err := models.DB.Create(&resume).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save resume data"})
+	if err := services.UpdateResume(tenantDB(c), &resume, changes); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update resume"})
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{
-		"message": "Resume uploaded and parsed successfully",
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Resume updated successfully",
 		"resume":  resume,
 	})
 }
+
+// DeleteResume removes a resume, its scores and the uploaded file.
+func DeleteResume(c *gin.Context) {
+	if err := services.DeleteResume(tenantDB(c), c.Param("id")); err != nil {
+		respondLookupError(c, err, "Resume not found")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Resume deleted successfully",
+	})
+}
@@ -0,0 +1,146 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/services"
+	"github.com/gin-gonic/gin"
+)
+
+type attachScoringProfileInput struct {
+	ScoringProfileID *string `json:"scoring_profile_id"` // null detaches the job from its profile
+}
+
+func respondScoringProfileError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrScoringProfileNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidScoringProfile):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrScoringProfileReadOnly):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrScoringProfileConflict), errors.Is(err, services.ErrScoringProfileInUse):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update scoring profile", "message": err.Error()})
+	}
+}
+
+// isPlatformAdmin reports whether the caller may change global profiles.
+func isPlatformAdmin(c *gin.Context) bool {
+	return c.GetString("platform_role") == models.RoleAdmin
+}
+
+// ListScoringProfiles lists the organization's profiles and the global ones.
+func ListScoringProfiles(c *gin.Context) {
+	profiles, err := scoringProfilesFromContext(c).List(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch scoring profiles"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"profiles": profiles,
+	})
+}
+
+func GetScoringProfile(c *gin.Context) {
+	profile, err := scoringProfilesFromContext(c).Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondScoringProfileError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"profile": profile,
+	})
+}
+
+func CreateScoringProfile(c *gin.Context) {
+	var profile models.ScoringProfile
+	if err := c.ShouldBindJSON(&profile); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	profile.ID = ""
+
+	if err := scoringProfilesFromContext(c).Create(c.Request.Context(), &profile); err != nil {
+		respondScoringProfileError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Scoring profile created successfully",
+		"profile": profile,
+	})
+}
+
+func UpdateScoringProfile(c *gin.Context) {
+	var input models.ScoringProfile
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	profile, err := scoringProfilesFromContext(c).Update(c.Request.Context(), c.Param("id"), &input, isPlatformAdmin(c))
+	if err != nil {
+		respondScoringProfileError(c, err)
+		return
+	}
+
+	jobRecommendationsFromContext(c).InvalidateJobs(c.Request.Context())
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Scoring profile updated successfully",
+		"profile": profile,
+	})
+}
+
+func DeleteScoringProfile(c *gin.Context) {
+	if err := scoringProfilesFromContext(c).Delete(c.Request.Context(), c.Param("id"), isPlatformAdmin(c)); err != nil {
+		respondScoringProfileError(c, err)
+		return
+	}
+
+	jobRecommendationsFromContext(c).InvalidateJobs(c.Request.Context())
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Scoring profile deleted successfully",
+	})
+}
+
+// AttachScoringProfile sets the profile a job is scored with.
+func AttachScoringProfile(c *gin.Context) {
+	var input attachScoringProfileInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var job models.JobDescription
+	if err := tenantDB(c).Where("id = ?", c.Param("jobId")).First(&job).Error; err != nil {
+		respondLookupError(c, err, "Job not found")
+		return
+	}
+
+	if input.ScoringProfileID != nil {
+		if _, err := scoringProfilesFromContext(c).Get(c.Request.Context(), *input.ScoringProfileID); err != nil {
+			respondScoringProfileError(c, err)
+			return
+		}
+	}
+
+	if err := tenantDB(c).Model(&job).Update("scoring_profile_id", input.ScoringProfileID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update job"})
+		return
+	}
+	job.ScoringProfileID = input.ScoringProfileID
+	jobRecommendationsFromContext(c).InvalidateJobs(c.Request.Context())
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Scoring profile attached successfully",
+		"job":     job,
+	})
+}
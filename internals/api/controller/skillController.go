@@ -0,0 +1,140 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/services"
+	"github.com/gin-gonic/gin"
+)
+
+type aliasesInput struct {
+	Aliases []string `json:"aliases" binding:"required,min=1"`
+}
+
+type relatedSkillsInput struct {
+	Related []string `json:"related" binding:"required,min=1"`
+}
+
+type mergeSkillInput struct {
+	TargetID string `json:"target_id" binding:"required"`
+}
+
+func respondSkillError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrSkillNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrSkillConflict):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update skill dictionary", "message": err.Error()})
+	}
+}
+
+func ListSkills(c *gin.Context) {
+	skills, err := skillDictionaryFromContext(c).List(c.Query("include_deprecated") == "true")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch skills"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"skills": skills,
+	})
+}
+
+func CreateSkill(c *gin.Context) {
+	var skill models.Skill
+	if err := c.ShouldBindJSON(&skill); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if skill.Name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Skill name is required"})
+		return
+	}
+	skill.ID = ""
+	skill.Deprecated = false
+
+	if err := skillDictionaryFromContext(c).Create(&skill); err != nil {
+		respondSkillError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Skill created successfully",
+		"skill":   skill,
+	})
+}
+
+func AddSkillAliases(c *gin.Context) {
+	var input aliasesInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	skill, err := skillDictionaryFromContext(c).AddAliases(c.Param("id"), input.Aliases)
+	if err != nil {
+		respondSkillError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Aliases added successfully",
+		"skill":   skill,
+	})
+}
+
+func AddRelatedSkills(c *gin.Context) {
+	var input relatedSkillsInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	skill, err := skillDictionaryFromContext(c).AddRelated(c.Param("id"), input.Related)
+	if err != nil {
+		respondSkillError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Related skills added successfully",
+		"skill":   skill,
+	})
+}
+
+func MergeSkill(c *gin.Context) {
+	var input mergeSkillInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	skill, err := skillDictionaryFromContext(c).Merge(c.Param("id"), input.TargetID)
+	if err != nil {
+		respondSkillError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Skills merged successfully",
+		"skill":   skill,
+	})
+}
+
+func DeprecateSkill(c *gin.Context) {
+	skill, err := skillDictionaryFromContext(c).Deprecate(c.Param("id"))
+	if err != nil {
+		respondSkillError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Skill deprecated successfully",
+		"skill":   skill,
+	})
+}
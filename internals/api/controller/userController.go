@@ -6,6 +6,7 @@ import (
 
 	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/database"
 	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/services"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 	"gorm.io/gorm"
@@ -48,6 +49,12 @@ func SignUp() gin.HandlerFunc {
 
 		newUser.Password = hashedPassword
 
+		// Registration is public, so self-signups get no permissions until
+		// an admin invites them into an organization or grants a role. The
+		// first admin comes from ADMIN_EMAIL.
+		newUser.ID = ""
+		newUser.Role = models.RolePending
+
 		if err := database.DB.Create(&newUser).Error; err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{
 				"error":   err.Error(),
@@ -60,9 +67,217 @@ func SignUp() gin.HandlerFunc {
 			"password": hashedPassword,
 			"name":     newUser.Name,
 			"phone":    newUser.Phone,
+			"role":     newUser.Role,
 		},
 			"message": "User created"})
 
 	}
 
 }
+
+// Login checks the email and password and returns a short-lived access
+// token and a refresh token.
+func Login() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input models.LoginInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if input.Email == "" || input.Password == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Email and password are required"})
+			return
+		}
+
+		user, tokens, err := authFromContext(c).Login(input.Email, input.Password, input.OrganizationID)
+		if errors.Is(err, services.ErrInvalidCredentials) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid email or password"})
+			return
+		}
+		if errors.Is(err, services.ErrNotOrganizationMember) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log in"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Logged in",
+			"tokens":  tokens,
+			"user":    userSummary(user),
+		})
+	}
+}
+
+type refreshInput struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// Refresh exchanges a refresh token for a new access and refresh token. The
+// old refresh token can't be used again.
+func Refresh() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input refreshInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		tokens, err := authFromContext(c).Refresh(c.Request.Context(), input.RefreshToken)
+		if errors.Is(err, services.ErrInvalidToken) || errors.Is(err, services.ErrTokenRevoked) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid refresh token"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh token"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Token refreshed",
+			"tokens":  tokens,
+		})
+	}
+}
+
+type switchOrganizationInput struct {
+	OrganizationID string `json:"organization_id" binding:"required"`
+}
+
+// SwitchOrganization returns tokens for another organization the caller
+// belongs to. The caller's current access token is revoked.
+func SwitchOrganization() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var input switchOrganizationInput
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		value, _ := c.Get("claims")
+		claims, ok := value.(*services.Claims)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
+			return
+		}
+
+		tokens, err := authFromContext(c).SwitchOrganization(c.Request.Context(), claims, input.OrganizationID)
+		if errors.Is(err, services.ErrNotOrganizationMember) {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, services.ErrInvalidToken) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to switch organization"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Organization switched",
+			"tokens":  tokens,
+		})
+	}
+}
+
+// Logout revokes the caller's access token and ends its session.
+func Logout() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		value, _ := c.Get("claims")
+		claims, ok := value.(*services.Claims)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
+			return
+		}
+
+		if err := authFromContext(c).Logout(c.Request.Context(), claims); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log out"})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"message": "Logged out"})
+	}
+}
+
+// ListUsers lists users with their platform roles, optionally only one role.
+func ListUsers(c *gin.Context) {
+	query := database.DB.Select("id", "name", "email", "phone", "role", "image_url").Order("email")
+	if role := c.Query("role"); role != "" {
+		query = query.Where("role = ?", role)
+	}
+
+	var users []models.User
+	if err := query.Find(&users).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch users"})
+		return
+	}
+
+	list := make([]gin.H, 0, len(users))
+	for _, user := range users {
+		list = append(list, userSummary(&user))
+	}
+	c.JSON(http.StatusOK, gin.H{"users": list})
+}
+
+type roleInput struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// SetUserRole changes a user's platform role, admin or pending. Admins
+// have the platform permissions in every organization. The user's session is ended so the
+// next login picks the new role up; access tokens already issued keep the
+// old role until they expire.
+func SetUserRole(c *gin.Context) {
+	var input roleInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := services.ValidatePlatformRole(input.Role); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var user models.User
+	if err := database.DB.Where("id = ?", c.Param("id")).First(&user).Error; err != nil {
+		respondLookupError(c, err, "User not found")
+		return
+	}
+	if user.ID == c.GetString("user_id") && input.Role != models.RoleAdmin {
+		c.JSON(http.StatusConflict, gin.H{"error": "Admins cannot remove their own admin role"})
+		return
+	}
+
+	user.Role = input.Role
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&user).Update("role", user.Role).Error; err != nil {
+			return err
+		}
+		return services.EndSessions(tx, user.ID)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update role"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Role updated",
+		"user":    userSummary(&user),
+	})
+}
+
+// userSummary is a user without the password hash.
+func userSummary(user *models.User) gin.H {
+	return gin.H{
+		"id":        user.ID,
+		"name":      user.Name,
+		"email":     user.Email,
+		"phone":     user.Phone,
+		"role":      user.Role,
+		"image_url": user.ImageUrl,
+	}
+}
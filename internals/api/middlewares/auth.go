@@ -1,60 +1,128 @@
 package middlewares
 
 import (
+	"errors"
 	"net/http"
+	"slices"
 	"strings"
-	"time"
 
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/database"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/services"
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v4"
 )
 
-var jwtSecret = []byte("your-secret-key") // In production, load from env
-
-type Claims struct {
-	UserID string `json:"user_id"`
-	Email  string `json:"email"`
-	jwt.RegisteredClaims
-}
-
 func AuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
-			c.Abort()
+			deny(c, http.StatusUnauthorized, models.AuditAuthenticationFailed, "", "Authorization header required")
 			return
 		}
 
-		tokenString := strings.Replace(authHeader, "Bearer ", "", 1)
-		claims := &Claims{}
-
-		token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-			return jwtSecret, nil
-		})
-
-		if err != nil || !token.Valid {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+		claims, err := parseToken(c, authHeader)
+		if errors.Is(err, services.ErrTokenRevoked) {
+			deny(c, http.StatusUnauthorized, models.AuditAuthenticationFailed, "", "Token has been revoked")
+			return
+		}
+		if errors.Is(err, services.ErrInvalidToken) {
+			deny(c, http.StatusUnauthorized, models.AuditAuthenticationFailed, "", "Invalid token")
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Could not verify token"})
 			c.Abort()
 			return
 		}
 
 		c.Set("user_id", claims.UserID)
 		c.Set("email", claims.Email)
+		c.Set("organization_id", claims.OrganizationID)
+		c.Set("role", claims.Role)
+		c.Set("platform_role", claims.PlatformRole)
+		c.Set("claims", claims)
+
+		// Queries run with the request context only see the token's
+		// organization.
+		if claims.OrganizationID != "" {
+			c.Request = c.Request.WithContext(database.WithOrganization(c.Request.Context(), claims.OrganizationID))
+		}
 		c.Next()
 	}
 }
 
-func GenerateToken(userID, email string) (string, error) {
-	claims := Claims{
-		UserID: userID,
-		Email:  email,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
+// RequirePermission lets the request through only if the caller's role
+// grants the permission. Platform permissions are checked against the
+// user's platform role; the others need an organization and are checked
+// against the role in it. It must run after AuthMiddleware.
+func RequirePermission(permission services.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if services.IsPlatformPermission(permission) {
+			if !services.HasPermission(c.GetString("platform_role"), permission) {
+				deny(c, http.StatusForbidden, models.AuditAuthorizationDenied, permission, "Insufficient permissions")
+				return
+			}
+			c.Next()
+			return
+		}
+
+		if c.GetString("organization_id") == "" {
+			deny(c, http.StatusForbidden, models.AuditAuthorizationDenied, permission, "No organization selected")
+			return
+		}
+		if !services.HasPermission(c.GetString("role"), permission) {
+			deny(c, http.StatusForbidden, models.AuditAuthorizationDenied, permission, "Insufficient permissions")
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireRole lets the request through only for the listed organization
+// roles. It must run after AuthMiddleware.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !slices.Contains(roles, c.GetString("role")) {
+			deny(c, http.StatusForbidden, models.AuditAuthorizationDenied, "", "Insufficient permissions")
+			return
+		}
+		c.Next()
+	}
+}
+
+// parseToken verifies a bearer access token with the auth service that main
+// puts in the context.
+func parseToken(c *gin.Context, authHeader string) (*services.Claims, error) {
+	value, _ := c.Get("auth")
+	auth, ok := value.(*services.AuthService)
+	if !ok {
+		return nil, errors.New("auth service not configured")
+	}
+
+	tokenString := strings.TrimSpace(strings.TrimPrefix(authHeader, "Bearer "))
+	return auth.Parse(c.Request.Context(), tokenString, services.AccessToken)
+}
+
+// deny aborts the request and records an audit event for it.
+func deny(c *gin.Context, status int, eventType string, permission services.Permission, reason string) {
+	value, _ := c.Get("audit")
+	if audit, ok := value.(*services.AuditService); ok {
+		event := &models.AuditEvent{
+			Type:       eventType,
+			Email:      c.GetString("email"),
+			Role:       c.GetString("role"),
+			Permission: string(permission),
+			Method:     c.Request.Method,
+			Path:       c.Request.URL.Path,
+			ClientIP:   c.ClientIP(),
+			Reason:     reason,
+		}
+		if userID := c.GetString("user_id"); userID != "" {
+			event.UserID = &userID
+		}
+		audit.Record(event)
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(jwtSecret)
+	c.JSON(status, gin.H{"error": reason})
+	c.Abort()
 }
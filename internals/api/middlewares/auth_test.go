@@ -0,0 +1,59 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/services"
+	"github.com/gin-gonic/gin"
+)
+
+// permissionStatus runs RequirePermission for a caller with the given
+// roles, as AuthMiddleware would have set them, and returns the status.
+func permissionStatus(permission services.Permission, platformRole, organizationID, role string) int {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/", func(c *gin.Context) {
+		c.Set("user_id", "user-1")
+		c.Set("platform_role", platformRole)
+		c.Set("organization_id", organizationID)
+		c.Set("role", role)
+	}, RequirePermission(permission), func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", nil))
+	return w.Code
+}
+
+func TestRequirePermissionOrganizationsCreate(t *testing.T) {
+	tests := []struct {
+		name                               string
+		platformRole, organizationID, role string
+		want                               int
+	}{
+		{"pending without organization", models.RolePending, "", "", http.StatusForbidden},
+		{"pending organization admin", models.RolePending, "org-1", models.RoleAdmin, http.StatusForbidden},
+		{"platform admin", models.RoleAdmin, "", "", http.StatusCreated},
+	}
+	for _, tt := range tests {
+		if got := permissionStatus(services.PermOrganizationsCreate, tt.platformRole, tt.organizationID, tt.role); got != tt.want {
+			t.Errorf("%s: status %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRequirePermissionOrganizationRole(t *testing.T) {
+	if got := permissionStatus(services.PermJobsWrite, models.RoleAdmin, "", ""); got != http.StatusForbidden {
+		t.Errorf("without organization: status %d, want %d", got, http.StatusForbidden)
+	}
+	if got := permissionStatus(services.PermJobsWrite, models.RolePending, "org-1", models.RoleRecruiter); got != http.StatusCreated {
+		t.Errorf("recruiter: status %d, want %d", got, http.StatusCreated)
+	}
+	if got := permissionStatus(services.PermJobsWrite, models.RolePending, "org-1", models.RoleViewer); got != http.StatusForbidden {
+		t.Errorf("viewer: status %d, want %d", got, http.StatusForbidden)
+	}
+}
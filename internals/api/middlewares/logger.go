@@ -1,6 +1,7 @@
 package middlewares
 
 import (
+	"fmt"
 	"log"
 	"time"
 
@@ -9,7 +10,7 @@ import (
 
 func LoggerMiddleware() gin.HandlerFunc {
 	return gin.LoggerWithFormatter(func(param gin.LogFormatterParams) string {
-		return log.Printf("[%s] %s %s %d %s %s\n",
+		return fmt.Sprintf("[%s] %s %s %d %s %s\n",
 			param.TimeStamp.Format(time.RFC822),
 			param.Method,
 			param.Path,
@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/api/controller"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/api/middlewares"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/services"
+	"github.com/gin-gonic/gin"
+)
+
+func AdminRoutes(r *gin.Engine) {
+	adminGroup := r.Group("/admin", middlewares.AuthMiddleware())
+	{
+		skillGroup := adminGroup.Group("/skills", middlewares.RequirePermission(services.PermSkillsManage))
+		skillGroup.GET("", controller.ListSkills)
+		skillGroup.POST("", controller.CreateSkill)
+		skillGroup.POST("/:id/aliases", controller.AddSkillAliases)
+		skillGroup.POST("/:id/related", controller.AddRelatedSkills)
+		skillGroup.POST("/:id/merge", controller.MergeSkill)
+		skillGroup.POST("/:id/deprecate", controller.DeprecateSkill)
+
+		userGroup := adminGroup.Group("/users", middlewares.RequirePermission(services.PermUsersManage))
+		userGroup.GET("", controller.ListUsers)
+		userGroup.PUT("/:id/role", controller.SetUserRole)
+
+		system := middlewares.RequirePermission(services.PermSystemManage)
+		adminGroup.GET("/ai-cache/stats", system, controller.GetAICacheStats)
+		adminGroup.DELETE("/ai-cache", system, controller.PurgeAICache)
+		adminGroup.GET("/ai/status", system, controller.GetAIStatus)
+		adminGroup.GET("/audit-events", system, controller.ListAuditEvents)
+	}
+}
@@ -1,15 +1,29 @@
-package jobRouter
+package routes
 
 import (
 	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/api/controller"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/api/middlewares"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/services"
 	"github.com/gin-gonic/gin"
 )
 
 func JobRoutes(r *gin.Engine) {
-	jobGroup := r.Group("/job")
+	read := middlewares.RequirePermission(services.PermJobsRead)
+	write := middlewares.RequirePermission(services.PermJobsWrite)
+	match := middlewares.RequirePermission(services.PermJobsMatch)
+	candidates := middlewares.RequirePermission(services.PermCandidatesRead)
+
+	jobGroup := r.Group("/job", middlewares.AuthMiddleware())
 	{
-		jobGroup.POST("/create", controller.CreateJob)
-		jobGroup.GET("/list", controller.GetJobs)
-		jobGroup.POST("/match/:jobId", controller.MatchCandidates)
+		jobGroup.POST("/create", write, controller.CreateJob)
+		jobGroup.GET("/list", read, controller.GetJobs)
+		jobGroup.GET("/:jobId", read, controller.GetJob)
+		jobGroup.PUT("/:jobId", write, controller.UpdateJob)
+		jobGroup.PATCH("/:jobId", write, controller.PatchJob)
+		jobGroup.DELETE("/:jobId", write, controller.DeleteJob)
+		jobGroup.POST("/match/:jobId", match, controller.MatchCandidates)
+		jobGroup.GET("/top/:jobId", candidates, controller.GetTopCandidates)
+		jobGroup.GET("/:jobId/candidates", candidates, controller.ListCandidates)
+		jobGroup.PUT("/:jobId/scoring-profile", write, controller.AttachScoringProfile)
 	}
 }
@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/api/controller"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/api/middlewares"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/services"
+	"github.com/gin-gonic/gin"
+)
+
+func MatchRunRoutes(r *gin.Engine) {
+	matchRunGroup := r.Group("/match-runs", middlewares.AuthMiddleware())
+	{
+		matchRunGroup.GET("/:id", middlewares.RequirePermission(services.PermJobsRead), controller.GetMatchRun)
+		matchRunGroup.POST("/:id/cancel", middlewares.RequirePermission(services.PermJobsMatch), controller.CancelMatchRun)
+	}
+}
@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/api/controller"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/api/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+func NotificationRoutes(r *gin.Engine) {
+	notificationGroup := r.Group("/notifications", middlewares.AuthMiddleware())
+	{
+		notificationGroup.GET("", controller.GetNotifications)
+		notificationGroup.POST("/:id/read", controller.MarkNotificationRead)
+	}
+}
@@ -0,0 +1,36 @@
+package routes
+
+import (
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/api/controller"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/api/middlewares"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/services"
+	"github.com/gin-gonic/gin"
+)
+
+// OrganizationRoutes registers /organizations, for the organizations the
+// caller belongs to, and /organization, for the one their token is for.
+func OrganizationRoutes(r *gin.Engine) {
+	manage := middlewares.RequirePermission(services.PermOrganizationManage)
+	member := middlewares.RequireRole(services.Roles...)
+
+	organizationsGroup := r.Group("/organizations", middlewares.AuthMiddleware())
+	{
+		organizationsGroup.GET("", controller.ListMyOrganizations)
+		organizationsGroup.POST("", middlewares.RequirePermission(services.PermOrganizationsCreate), controller.CreateOrganization)
+		organizationsGroup.POST("/invites/accept", controller.AcceptOrganizationInvite)
+	}
+
+	organizationGroup := r.Group("/organization", middlewares.AuthMiddleware())
+	{
+		organizationGroup.GET("", member, controller.GetOrganization)
+		organizationGroup.PUT("/settings", manage, controller.UpdateOrganizationSettings)
+
+		organizationGroup.GET("/members", manage, controller.ListOrganizationMembers)
+		organizationGroup.PUT("/members/:userId/role", manage, controller.SetOrganizationMemberRole)
+		organizationGroup.DELETE("/members/:userId", manage, controller.RemoveOrganizationMember)
+
+		organizationGroup.POST("/invites", manage, controller.CreateOrganizationInvite)
+		organizationGroup.GET("/invites", manage, controller.ListOrganizationInvites)
+		organizationGroup.DELETE("/invites/:id", manage, controller.RevokeOrganizationInvite)
+	}
+}
@@ -1,13 +1,24 @@
-package resumeRouter
+package routes
 
 import (
 	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/api/controller"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/api/middlewares"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/services"
 	"github.com/gin-gonic/gin"
 )
 
 func ResumeRoutes(r *gin.Engine) {
-	resumeGroup := r.Group("/resume")
+	read := middlewares.RequirePermission(services.PermResumesRead)
+	write := middlewares.RequirePermission(services.PermResumesWrite)
+
+	resumeGroup := r.Group("/resume", middlewares.AuthMiddleware())
 	{
-		resumeGroup.POST("/upload", controller.UploadResume)
+		resumeGroup.POST("/upload", write, controller.UploadResume)
+		resumeGroup.GET("", read, controller.ListResumes)
+		resumeGroup.GET("/:id", read, controller.GetResume)
+		resumeGroup.PUT("/:id", write, controller.ReplaceResume)
+		resumeGroup.PATCH("/:id", write, controller.PatchResume)
+		resumeGroup.DELETE("/:id", write, controller.DeleteResume)
+		resumeGroup.GET("/:id/recommended-jobs", read, controller.GetRecommendedJobs)
 	}
 }
@@ -14,6 +14,11 @@ func SetUpRoutes(router *gin.Engine) {
 	)
 
 	UserRoutes(router)
+	OrganizationRoutes(router)
 	ResumeRoutes(router)
 	JobRoutes(router)
+	ScoringProfileRoutes(router)
+	MatchRunRoutes(router)
+	NotificationRoutes(router)
+	AdminRoutes(router)
 }
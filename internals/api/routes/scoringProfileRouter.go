@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/api/controller"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/api/middlewares"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/services"
+	"github.com/gin-gonic/gin"
+)
+
+func ScoringProfileRoutes(r *gin.Engine) {
+	read := middlewares.RequirePermission(services.PermScoringProfilesRead)
+	write := middlewares.RequirePermission(services.PermScoringProfilesWrite)
+
+	profileGroup := r.Group("/scoring-profiles", middlewares.AuthMiddleware())
+	{
+		profileGroup.GET("", read, controller.ListScoringProfiles)
+		profileGroup.POST("", write, controller.CreateScoringProfile)
+		profileGroup.GET("/:id", read, controller.GetScoringProfile)
+		profileGroup.PUT("/:id", write, controller.UpdateScoringProfile)
+		profileGroup.DELETE("/:id", write, controller.DeleteScoringProfile)
+	}
+}
@@ -1,7 +1,8 @@
-package userRouter
+package routes
 
 import (
 	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/api/controller"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/api/middlewares"
 	"github.com/gin-gonic/gin"
 )
 
@@ -9,5 +10,9 @@ func UserRoutes(r *gin.Engine) {
 	userGroup := r.Group("/user")
 	{
 		userGroup.POST("/register", controller.SignUp())
+		userGroup.POST("/login", controller.Login())
+		userGroup.POST("/refresh", controller.Refresh())
+		userGroup.POST("/logout", middlewares.AuthMiddleware(), controller.Logout())
+		userGroup.POST("/switch-organization", middlewares.AuthMiddleware(), controller.SwitchOrganization())
 	}
 }
@@ -0,0 +1,260 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/config"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/database"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/services"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/taxonomy"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// App holds the connections and services shared by the API and the worker.
+type App struct {
+	Config          config.Config
+	DB              *gorm.DB
+	Redis           *redis.Client
+	AIService       *services.AIService // nil when AI is disabled
+	SkillDictionary *services.SkillDictionaryService
+	ScoringProfiles *services.ScoringProfileService
+	MatchRuns       *services.MatchRunService
+	Recommendations *services.JobRecommendationService
+	Auth            *services.AuthService
+	Audit           *services.AuditService
+	Organizations   *services.OrganizationService
+	AIRegistry      *services.AIRegistry // AI service per organization settings
+}
+
+// New connects to Postgres and Redis and builds the services.
+func New(cfg config.Config) (*App, error) {
+	redisClient, err := database.RedisConnection(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := database.ConnectDB(&cfg)
+	if err != nil {
+		redisClient.Close()
+		return nil, err
+	}
+
+	// Initialize AI service
+	var aiService *services.AIService
+	if cfg.AIEnabled() {
+		aiService, err = services.NewAIService(cfg)
+		if err != nil {
+			log.Printf("Warning: Failed to initialize AI service: %v", err)
+		} else {
+			log.Println("AI service initialized successfully")
+			if cfg.AICacheTTL > 0 {
+				aiService.SetCache(services.NewAICache(redisClient, cfg.AICacheTTL))
+			}
+		}
+	} else {
+		log.Println("Warning: AI provider not configured, AI features will be disabled")
+	}
+
+	signingKeys, err := services.ParseSigningKeys(cfg.JWTKeyID, cfg.JWTSecret, cfg.JWTPreviousKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	organizations := services.NewOrganizationService(db)
+	aiRegistry := services.NewAIRegistry(cfg, aiService, organizations)
+	scoringProfiles := services.NewScoringProfileService(db)
+	scoringProfiles.SetOrganizations(organizations)
+	matcher := services.NewJobMatcherService(aiService)
+	matcher.SetAIRegistry(aiRegistry)
+
+	return &App{
+		Config:          cfg,
+		DB:              db,
+		Redis:           redisClient,
+		AIService:       aiService,
+		SkillDictionary: services.NewSkillDictionaryService(db, redisClient, taxonomy.Default()),
+		ScoringProfiles: scoringProfiles,
+		MatchRuns:       services.NewMatchRunService(db, matcher, scoringProfiles, cfg.MatchConcurrency, cfg.MatchBatchSize),
+		Recommendations: services.NewJobRecommendationService(db, redisClient, matcher, scoringProfiles),
+		Auth:            services.NewAuthService(db, redisClient, organizations, signingKeys, cfg.JWTAccessTTL, cfg.JWTRefreshTTL),
+		Audit:           services.NewAuditService(db),
+		Organizations:   organizations,
+		AIRegistry:      aiRegistry,
+	}, nil
+}
+
+// Migrate creates or updates the schema, seeds the skill dictionary and
+// default scoring profile, promotes ADMIN_EMAIL to admin and moves data
+// from before organizations into a default organization.
+func (a *App) Migrate() error {
+	if err := dedupeCandidateScores(a.DB); err != nil {
+		return err
+	}
+	// Scoring profile names used to be unique across all organizations.
+	if a.DB.Migrator().HasIndex(&models.ScoringProfile{}, "idx_scoring_profiles_name") {
+		if err := a.DB.Migrator().DropIndex(&models.ScoringProfile{}, "idx_scoring_profiles_name"); err != nil {
+			return err
+		}
+	}
+	// Refresh tokens moved from users to refresh_sessions.
+	if a.DB.Migrator().HasColumn(&models.User{}, "refresh_token") {
+		if err := a.DB.Migrator().DropColumn(&models.User{}, "refresh_token"); err != nil {
+			return err
+		}
+	}
+	if err := a.DB.AutoMigrate(&models.User{}, &models.JobDescription{}, &models.Resume{}, &models.CandidateScore{}, &models.CandidateScoreHistory{}, &models.Education{}, &models.Experience{}, &models.Skill{}, &models.ScoringProfile{}, &models.MatchRun{}, &models.Notification{}, &models.AuditEvent{}, &models.Organization{}, &models.OrganizationMember{}, &models.OrganizationInvite{}, &models.RefreshSession{}); err != nil {
+		return err
+	}
+	if err := a.SkillDictionary.Seed(); err != nil {
+		return err
+	}
+	if err := promoteAdmin(a.DB, a.Config.AdminEmail); err != nil {
+		return err
+	}
+	if err := backfillOrganization(a.DB); err != nil {
+		return err
+	}
+	if err := backfillResumeLocations(a.DB); err != nil {
+		return err
+	}
+	return a.ScoringProfiles.Seed()
+}
+
+// legacyOrganizationName names the organization that data from before
+// organizations is moved into.
+const legacyOrganizationName = "Default"
+
+// backfillOrganization moves rows without an organization into the legacy
+// organization. Each table is checked for such rows on every start, so a
+// table that gains its organization_id column later, or rows written
+// without one, are picked up too. The legacy organization is created the
+// first time any table needs it, and every user except pending self-signups
+// then becomes a member with their current role.
+func backfillOrganization(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var organization *models.Organization
+		for _, model := range []interface{}{&models.JobDescription{}, &models.Resume{}, &models.CandidateScore{}, &models.MatchRun{}, &models.ScoringProfile{}} {
+			orphans := func() *gorm.DB {
+				query := tx.Unscoped().Model(model).Where("organization_id IS NULL")
+				if _, ok := model.(*models.ScoringProfile); ok {
+					// The seeded default stays global.
+					query = query.Where("name <> ?", services.DefaultScoringProfileName)
+				}
+				return query
+			}
+
+			var count int64
+			if err := orphans().Count(&count).Error; err != nil {
+				return err
+			}
+			if count == 0 {
+				continue
+			}
+
+			if organization == nil {
+				var err error
+				if organization, err = legacyOrganization(tx); err != nil {
+					return err
+				}
+			}
+			result := orphans().Update("organization_id", organization.ID)
+			if result.Error != nil {
+				return result.Error
+			}
+			log.Printf("Moved %d %s rows into organization %s", result.RowsAffected, result.Statement.Table, organization.ID)
+		}
+		return nil
+	})
+}
+
+// legacyOrganization returns the oldest organization named
+// legacyOrganizationName, creating it with every non-pending user as a
+// member when there is none.
+func legacyOrganization(tx *gorm.DB) (*models.Organization, error) {
+	var organization models.Organization
+	err := tx.Where("name = ?", legacyOrganizationName).Order("created_at").First(&organization).Error
+	if err == nil {
+		return &organization, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	organization = models.Organization{Name: legacyOrganizationName}
+	if err := tx.Create(&organization).Error; err != nil {
+		return nil, err
+	}
+	result := tx.Exec(`INSERT INTO organization_members (organization_id, user_id, role, created_at)
+		SELECT ?, id, role, NOW() FROM users WHERE role <> ?`, organization.ID, models.RolePending)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	log.Printf("Created organization %s for existing data with %d members", organization.ID, result.RowsAffected)
+	return &organization, nil
+}
+
+// promoteAdmin makes the user with the configured email an admin. It is the
+// only way to get the first admin, since self-signups start as pending.
+func promoteAdmin(db *gorm.DB, email string) error {
+	if email == "" {
+		return nil
+	}
+	result := db.Model(&models.User{}).Where("email = ? AND role <> ?", email, models.RoleAdmin).Update("role", models.RoleAdmin)
+	if result.RowsAffected > 0 {
+		log.Printf("Promoted %s to admin", email)
+	}
+	return result.Error
+}
+
+// backfillResumeLocations parses the location of resumes stored before
+// resumes had one, so location knockout rules can check them. Resumes
+// without a recognisable location get "" and are not parsed again.
+func backfillResumeLocations(db *gorm.DB) error {
+	var resumes []models.Resume
+	return db.Model(&models.Resume{}).Select("id", "parsed_text").Where("location IS NULL").
+		FindInBatches(&resumes, 500, func(tx *gorm.DB, batch int) error {
+			for _, resume := range resumes {
+				location := services.ParseResumeLocation(resume.ParsedText)
+				if err := db.Model(&models.Resume{}).Where("id = ?", resume.ID).UpdateColumn("location", location).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Error
+}
+
+// dedupeCandidateScores keeps only the newest score per job and resume, so
+// the unique index on candidate_scores can be created on databases that
+// still hold the duplicates earlier versions inserted on every match.
+func dedupeCandidateScores(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasTable(&models.CandidateScore{}) || migrator.HasIndex(&models.CandidateScore{}, "idx_candidate_scores_job_resume") {
+		return nil
+	}
+
+	return db.Exec(`DELETE FROM candidate_scores a USING candidate_scores b
+		WHERE a.job_id = b.job_id AND a.resume_id = b.resume_id
+		AND (a.created_at < b.created_at OR (a.created_at = b.created_at AND a.id < b.id))`).Error
+}
+
+// Start loads the skill dictionary and follows changes made by other
+// instances until ctx is cancelled.
+func (a *App) Start(ctx context.Context) error {
+	if err := a.SkillDictionary.Reload(); err != nil {
+		return err
+	}
+	go a.SkillDictionary.Watch(ctx)
+	return nil
+}
+
+// Close releases the AI provider clients.
+func (a *App) Close() {
+	if a.AIService != nil {
+		a.AIService.Close()
+	}
+	a.AIRegistry.Close()
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"log"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -12,6 +13,59 @@ type Config struct {
 	Database string `mapstructure:"DATABASE_URL"`
 	RedisURL string `mapstructure:"REDIS_URL"`
 	AIAPIKey string `mapstructure:"AI_API_KEY"`
+
+	// AI provider selection. AIProvider and AIModel are the defaults; each
+	// operation can override them with its own provider and model.
+	AIProvider        string `mapstructure:"AI_PROVIDER"` // gemini, openai or ollama
+	AIModel           string `mapstructure:"AI_MODEL"`
+	AIMatchProvider   string `mapstructure:"AI_MATCH_PROVIDER"`
+	AIMatchModel      string `mapstructure:"AI_MATCH_MODEL"`
+	AIExtractProvider string `mapstructure:"AI_EXTRACT_PROVIDER"`
+	AIExtractModel    string `mapstructure:"AI_EXTRACT_MODEL"`
+	AISummaryProvider string `mapstructure:"AI_SUMMARY_PROVIDER"`
+	AISummaryModel    string `mapstructure:"AI_SUMMARY_MODEL"`
+	OpenAIBaseURL     string `mapstructure:"OPENAI_BASE_URL"` // Any OpenAI-compatible chat completions endpoint
+	OpenAIAPIKey      string `mapstructure:"OPENAI_API_KEY"`  // Falls back to AI_API_KEY
+	OllamaBaseURL     string `mapstructure:"OLLAMA_BASE_URL"`
+
+	AIMaxRepairAttempts int           `mapstructure:"AI_MAX_REPAIR_ATTEMPTS"` // Re-prompts for malformed JSON replies
+	AICacheTTL          time.Duration `mapstructure:"AI_CACHE_TTL"`           // How long AI results stay cached, 0 disables the cache
+	AITimeout           time.Duration `mapstructure:"AI_TIMEOUT"`             // Deadline for a single provider call
+	AIMaxRetries        int           `mapstructure:"AI_MAX_RETRIES"`         // Retries for rate limits, timeouts and 5xx errors
+	AIBreakerThreshold  int           `mapstructure:"AI_BREAKER_THRESHOLD"`   // Consecutive failures before the circuit opens
+	AIBreakerCooldown   time.Duration `mapstructure:"AI_BREAKER_COOLDOWN"`    // How long the circuit stays open before a probe
+	AIRateLimit         int           `mapstructure:"AI_RATE_LIMIT"`          // Provider calls per minute across the process, 0 for no limit
+
+	MatchConcurrency int `mapstructure:"MATCH_CONCURRENCY"` // Resumes scored in parallel by bulk matching
+	MatchBatchSize   int `mapstructure:"MATCH_BATCH_SIZE"`  // Resumes loaded and scores inserted per batch
+	MatchWorkers     int `mapstructure:"MATCH_WORKERS"`     // Match run workers inside the API process, 0 when using cmd/worker
+	AutoMatchTopN    int `mapstructure:"AUTO_MATCH_TOP_N"`  // Candidates listed in auto-match notifications
+
+	// JWT signing. New tokens are signed with JWT_SECRET under JWT_KEY_ID;
+	// JWT_PREVIOUS_KEYS lists retired keys as id:secret,id:secret so tokens
+	// they signed stay valid until they expire.
+	JWTSecret       string        `mapstructure:"JWT_SECRET"`
+	JWTKeyID        string        `mapstructure:"JWT_KEY_ID"`
+	JWTPreviousKeys string        `mapstructure:"JWT_PREVIOUS_KEYS"`
+	JWTAccessTTL    time.Duration `mapstructure:"JWT_ACCESS_TTL"`
+	JWTRefreshTTL   time.Duration `mapstructure:"JWT_REFRESH_TTL"`
+
+	AdminEmail string `mapstructure:"ADMIN_EMAIL"` // Promoted to admin on startup
+
+	RetentionInterval time.Duration `mapstructure:"RETENTION_INTERVAL"` // How often resumes past their organization's retention are deleted, 0 disables
+}
+
+// AIEnabled reports whether enough configuration is present to build an AI
+// service. Local providers don't need an API key.
+func (c Config) AIEnabled() bool {
+	switch c.AIProvider {
+	case "ollama":
+		return true
+	case "openai":
+		return c.OpenAIAPIKey != "" || c.AIAPIKey != ""
+	default:
+		return c.AIAPIKey != ""
+	}
 }
 
 func LoadConfig() (Config, error) {
@@ -23,6 +77,38 @@ func LoadConfig() (Config, error) {
 	viper.AutomaticEnv()
 	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 
+	// Defaults also register the keys, so they can be set from the
+	// environment when there is no .env file.
+	viper.SetDefault("AI_PROVIDER", "gemini")
+	viper.SetDefault("AI_MODEL", "")
+	viper.SetDefault("AI_MATCH_PROVIDER", "")
+	viper.SetDefault("AI_MATCH_MODEL", "")
+	viper.SetDefault("AI_EXTRACT_PROVIDER", "")
+	viper.SetDefault("AI_EXTRACT_MODEL", "")
+	viper.SetDefault("AI_SUMMARY_PROVIDER", "")
+	viper.SetDefault("AI_SUMMARY_MODEL", "")
+	viper.SetDefault("OPENAI_BASE_URL", "https://api.openai.com/v1")
+	viper.SetDefault("OPENAI_API_KEY", "")
+	viper.SetDefault("OLLAMA_BASE_URL", "http://localhost:11434")
+	viper.SetDefault("AI_MAX_REPAIR_ATTEMPTS", 2)
+	viper.SetDefault("AI_CACHE_TTL", "24h")
+	viper.SetDefault("AI_TIMEOUT", "30s")
+	viper.SetDefault("AI_MAX_RETRIES", 2)
+	viper.SetDefault("AI_BREAKER_THRESHOLD", 5)
+	viper.SetDefault("AI_BREAKER_COOLDOWN", "30s")
+	viper.SetDefault("AI_RATE_LIMIT", 0)
+	viper.SetDefault("MATCH_CONCURRENCY", 4)
+	viper.SetDefault("MATCH_BATCH_SIZE", 100)
+	viper.SetDefault("MATCH_WORKERS", 1)
+	viper.SetDefault("AUTO_MATCH_TOP_N", 5)
+	viper.SetDefault("JWT_SECRET", "")
+	viper.SetDefault("JWT_KEY_ID", "primary")
+	viper.SetDefault("JWT_PREVIOUS_KEYS", "")
+	viper.SetDefault("JWT_ACCESS_TTL", "15m")
+	viper.SetDefault("JWT_REFRESH_TTL", "720h")
+	viper.SetDefault("ADMIN_EMAIL", "")
+	viper.SetDefault("RETENTION_INTERVAL", "1h")
+
 	if err := viper.ReadInConfig(); err != nil {
 		log.Println("⚠️ No .env file found, falling back to environment variables")
 	}
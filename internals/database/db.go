@@ -22,6 +22,10 @@ func ConnectDB(cfg *config.Config) (*gorm.DB, error) {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 
+	if err := RegisterTenantScope(db); err != nil {
+		log.Fatalf("Failed to register tenant scope: %v", err)
+	}
+
 	sqlDB, err := db.DB()
 
 	if err != nil {
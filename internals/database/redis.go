@@ -2,6 +2,8 @@ package database
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
 
 	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/config"
@@ -13,23 +15,22 @@ var (
 	RedisClient *redis.Client
 )
 
-func RedisConnection(cfg config.Config) *redis.Client {
+func RedisConnection(cfg config.Config) (*redis.Client, error) {
 	if cfg.RedisURL == "" {
-		log.Fatalf("❌ REDIS_URL not set in environment")
+		return nil, errors.New("REDIS_URL not set in environment")
 	}
 
 	opt, err := redis.ParseURL(cfg.RedisURL)
-
 	if err != nil {
-		log.Fatalf("Could not parse Redis URL: %v", err)
+		return nil, fmt.Errorf("could not parse Redis URL: %w", err)
 	}
 	RedisClient = redis.NewClient(opt)
 
 	if _, err := RedisClient.Ping(Ctx).Result(); err != nil {
-		log.Fatalf("Could not connect to Redis: %v", err)
-	} else {
-		log.Println("Redis connection established")
+		RedisClient.Close()
+		return nil, fmt.Errorf("could not connect to Redis: %w", err)
 	}
+	log.Println("Redis connection established")
 
-	return RedisClient
+	return RedisClient, nil
 }
@@ -0,0 +1,121 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type organizationKey struct{}
+
+// WithOrganization scopes every query run with the returned context to one
+// organization. Models with an OrganizationID field are filtered by it, and
+// new rows get it set.
+func WithOrganization(ctx context.Context, organizationID string) context.Context {
+	return context.WithValue(ctx, organizationKey{}, organizationID)
+}
+
+// OrganizationFromContext returns the organization set by WithOrganization.
+func OrganizationFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(organizationKey{}).(string)
+	return id, ok && id != ""
+}
+
+// SharedModel is implemented by models whose rows without an organization
+// are shared by every organization. Scoped reads return them too; scoped
+// updates and deletes still only touch the organization's own rows.
+type SharedModel interface {
+	SharedAcrossOrganizations()
+}
+
+// RegisterTenantScope installs the callbacks behind WithOrganization. Raw
+// SQL is not scoped, so tenant data must be read through models.
+func RegisterTenantScope(db *gorm.DB) error {
+	callbacks := db.Callback()
+	if err := callbacks.Query().Before("gorm:query").Register("tenant:query", scopeReads); err != nil {
+		return err
+	}
+	if err := callbacks.Row().Before("gorm:row").Register("tenant:row", scopeReads); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("tenant:update", scopeWrites); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().Before("gorm:delete").Register("tenant:delete", scopeWrites); err != nil {
+		return err
+	}
+	return callbacks.Create().Before("gorm:create").Register("tenant:create", assignOrganization)
+}
+
+func scopeReads(db *gorm.DB) {
+	scopeToOrganization(db, true)
+}
+
+func scopeWrites(db *gorm.DB) {
+	scopeToOrganization(db, false)
+}
+
+func scopeToOrganization(db *gorm.DB, includeShared bool) {
+	organizationID, ok := OrganizationFromContext(db.Statement.Context)
+	if !ok || db.Statement.Schema == nil {
+		return
+	}
+	field := db.Statement.Schema.LookUpField("OrganizationID")
+	if field == nil {
+		return
+	}
+
+	column := clause.Column{Table: clause.CurrentTable, Name: field.DBName}
+	var scope clause.Expression = clause.Eq{Column: column, Value: organizationID}
+	if _, shared := reflect.New(db.Statement.Schema.ModelType).Interface().(SharedModel); shared && includeShared {
+		scope = clause.Or(scope, clause.Eq{Column: column, Value: nil})
+	}
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{scope}})
+}
+
+// assignOrganization sets OrganizationID on new rows and refuses rows that
+// belong to another organization.
+func assignOrganization(db *gorm.DB) {
+	organizationID, ok := OrganizationFromContext(db.Statement.Context)
+	if !ok || db.Statement.Schema == nil {
+		return
+	}
+	field := db.Statement.Schema.LookUpField("OrganizationID")
+	if field == nil {
+		return
+	}
+
+	assign := func(row reflect.Value) {
+		value, isZero := field.ValueOf(db.Statement.Context, row)
+		if !isZero {
+			if current, ok := value.(*string); ok && current != nil && *current != organizationID {
+				db.AddError(fmt.Errorf("%s belongs to another organization", db.Statement.Schema.Name))
+				return
+			}
+			if current, ok := value.(string); ok && current != organizationID {
+				db.AddError(fmt.Errorf("%s belongs to another organization", db.Statement.Schema.Name))
+				return
+			}
+			return
+		}
+		if err := field.Set(db.Statement.Context, row, organizationID); err != nil {
+			db.AddError(err)
+		}
+	}
+
+	rows := db.Statement.ReflectValue
+	switch rows.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rows.Len(); i++ {
+			row := reflect.Indirect(rows.Index(i))
+			if row.Kind() == reflect.Struct {
+				assign(row)
+			}
+		}
+	case reflect.Struct:
+		assign(rows)
+	}
+}
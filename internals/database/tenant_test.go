@@ -0,0 +1,98 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type tenantRow struct {
+	ID             string
+	OrganizationID *string
+}
+
+type sharedRow struct {
+	ID             string
+	OrganizationID *string
+}
+
+func (sharedRow) SharedAcrossOrganizations() {}
+
+func dryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterTenantScope(db); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestTenantScope(t *testing.T) {
+	db := dryRunDB(t)
+	ctx := WithOrganization(context.Background(), "org-1")
+
+	tests := []struct {
+		name  string
+		query func(tx *gorm.DB) *gorm.DB
+		want  string
+	}{
+		{
+			"query",
+			func(tx *gorm.DB) *gorm.DB { return tx.WithContext(ctx).Find(&[]tenantRow{}) },
+			`WHERE "tenant_rows"."organization_id" = 'org-1'`,
+		},
+		{
+			"shared query",
+			func(tx *gorm.DB) *gorm.DB { return tx.WithContext(ctx).Find(&[]sharedRow{}) },
+			`WHERE ("shared_rows"."organization_id" = 'org-1' OR "shared_rows"."organization_id" IS NULL)`,
+		},
+		{
+			"shared update",
+			func(tx *gorm.DB) *gorm.DB {
+				return tx.WithContext(ctx).Model(&sharedRow{}).Where("id = ?", "1").Update("id", "2")
+			},
+			`WHERE id = '1' AND "shared_rows"."organization_id" = 'org-1'`,
+		},
+		{
+			"no organization",
+			func(tx *gorm.DB) *gorm.DB { return tx.Find(&[]tenantRow{}) },
+			`SELECT * FROM "tenant_rows"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql := db.ToSQL(tt.query)
+			if !strings.HasSuffix(sql, tt.want) {
+				t.Errorf("got %s\nwant suffix %s", sql, tt.want)
+			}
+		})
+	}
+}
+
+func TestTenantCreate(t *testing.T) {
+	db := dryRunDB(t)
+	ctx := WithOrganization(context.Background(), "org-1")
+
+	row := tenantRow{ID: "1"}
+	if err := db.WithContext(ctx).Create(&row).Error; err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if row.OrganizationID == nil || *row.OrganizationID != "org-1" {
+		t.Errorf("organization not assigned: %v", row.OrganizationID)
+	}
+
+	other := "org-2"
+	if err := db.WithContext(ctx).Create(&tenantRow{ID: "2", OrganizationID: &other}).Error; err == nil {
+		t.Error("created a row for another organization")
+	}
+}
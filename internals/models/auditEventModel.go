@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+)
+
+// Audit event types.
+const (
+	AuditAuthenticationFailed = "authentication_failed"
+	AuditAuthorizationDenied  = "authorization_denied"
+)
+
+// AuditEvent records a security-relevant request, such as a caller without
+// the permission a route needs.
+type AuditEvent struct {
+	ID         string    `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	Type       string    `gorm:"not null;index" json:"type"`
+	UserID     *string   `gorm:"type:uuid;index" json:"user_id"` // Empty when the caller could not be identified
+	Email      string    `gorm:"null" json:"email"`
+	Role       string    `gorm:"null" json:"role"`
+	Permission string    `gorm:"null" json:"permission"` // Permission the route required
+	Method     string    `gorm:"not null" json:"method"`
+	Path       string    `gorm:"not null" json:"path"`
+	ClientIP   string    `gorm:"null" json:"client_ip"`
+	Reason     string    `gorm:"type:text" json:"reason"`
+	CreatedAt  time.Time `gorm:"autoCreateTime;index" json:"created_at"`
+}
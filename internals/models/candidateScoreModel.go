@@ -4,22 +4,89 @@ import (
 	"time"
 )
 
+// CandidateScore is the current score of a resume for a job. There is one
+// row per job and resume; re-scoring replaces it and moves the previous
+// values to CandidateScoreHistory.
 type CandidateScore struct {
-	ID             string    `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
-	ResumeID       string    `gorm:"not null" json:"resume_id"`
-	JobID          string    `gorm:"not null" json:"job_id"`
-	Score          int       `gorm:"not null" json:"score"` // 0-100
-	RequiredMatch  float64   `gorm:"not null" json:"required_match"` // Percentage of required skills matched
-	NiceToHaveMatch float64  `gorm:"not null" json:"nice_to_have_match"` // Percentage of nice-to-have skills matched
-	ExperienceMatch float64  `gorm:"not null" json:"experience_match"` // Experience match score
-	EducationMatch  float64  `gorm:"not null" json:"education_match"` // Education match score
-	AIEnhanced     bool      `gorm:"default:false" json:"ai_enhanced"` // Whether AI was used for scoring
-	AIScore        float64   `gorm:"default:0" json:"ai_score"` // AI-generated score component
-	AIReasoning    string    `gorm:"type:text" json:"ai_reasoning"` // AI reasoning for the match
-	CreatedAt      time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt      time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	ID                      string          `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	ResumeID                string          `gorm:"not null;uniqueIndex:idx_candidate_scores_job_resume,priority:2" json:"resume_id"`
+	JobID                   string          `gorm:"not null;uniqueIndex:idx_candidate_scores_job_resume,priority:1" json:"job_id"`
+	OrganizationID          *string         `gorm:"type:uuid;index" json:"organization_id"`
+	Score                   int             `gorm:"not null" json:"score"`                                 // 0-100
+	RequiredMatch           float64         `gorm:"not null" json:"required_match"`                        // Percentage of required skills matched
+	NiceToHaveMatch         float64         `gorm:"not null" json:"nice_to_have_match"`                    // Percentage of nice-to-have skills matched
+	RequiredSkills          []SkillMatch    `gorm:"type:jsonb;serializer:json" json:"required_skills"`     // Per-skill breakdown behind RequiredMatch
+	NiceToHaveSkills        []SkillMatch    `gorm:"type:jsonb;serializer:json" json:"nice_to_have_skills"` // Per-skill breakdown behind NiceToHaveMatch
+	ExperienceMatch         float64         `gorm:"not null" json:"experience_match"`                      // Experience match score
+	EducationMatch          float64         `gorm:"not null" json:"education_match"`                       // Education match score
+	EducationLevel          string          `gorm:"null" json:"education_level"`                           // Candidate's highest degree: high_school, associate, bachelor, master or phd
+	ExperienceMonths        int             `gorm:"default:0" json:"experience_months"`                    // Total months of experience, overlapping jobs merged
+	EmploymentGaps          []EmploymentGap `gorm:"type:jsonb;serializer:json" json:"employment_gaps"`     // Breaks between jobs
+	AIEnhanced              bool            `gorm:"default:false" json:"ai_enhanced"`                      // Whether AI was used for scoring
+	AIScore                 float64         `gorm:"default:0" json:"ai_score"`                             // AI-generated score component
+	AIReasoning             string          `gorm:"type:text" json:"ai_reasoning"`                         // AI reasoning for the match
+	AIMatchedSkills         []string        `gorm:"type:text[]" json:"ai_matched_skills"`                  // Skills the AI found on the resume
+	AIMissingSkills         []string        `gorm:"type:text[]" json:"ai_missing_skills"`                  // Skills the AI found missing
+	AIExperienceMatch       float64         `gorm:"default:0" json:"ai_experience_match"`                  // AI experience sub-score, 0-100
+	AIEducationMatch        float64         `gorm:"default:0" json:"ai_education_match"`                   // AI education sub-score, 0-100
+	ScoringMode             string          `gorm:"default:'heuristic'" json:"scoring_mode"`               // heuristic, ai or hybrid
+	Disqualified            bool            `gorm:"default:false;index" json:"disqualified"`               // Failed a knockout rule on the job
+	DisqualificationReasons []string        `gorm:"type:text[]" json:"disqualification_reasons"`           // One entry per failed rule
+	ScoringProfileID        *string         `gorm:"type:uuid" json:"scoring_profile_id"`                   // Profile whose weights produced Score
+	ScoringProfileVersion   int             `gorm:"default:0" json:"scoring_profile_version"`              // Version of that profile at scoring time
+	AlgorithmVersion        string          `gorm:"null" json:"algorithm_version"`                         // Version of the heuristic scoring code
+	AIModel                 string          `gorm:"null" json:"ai_model"`                                  // Provider and model behind the AI fields
+	ScoredAt                *time.Time      `json:"scored_at"`                                             // When the score was computed
+	Stale                   bool            `gorm:"default:false;index" json:"stale"`                      // The job's requirements changed after scoring
+	CreatedAt               time.Time       `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt               time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
 
 	// Relations
-	Resume Resume `gorm:"foreignKey:ResumeID" json:"resume"`
-	Job    JobDescription `gorm:"foreignKey:JobID" json:"job"`
+	Resume Resume          `gorm:"foreignKey:ResumeID" json:"resume"`
+	Job    *JobDescription `gorm:"foreignKey:JobID" json:"job,omitempty"` // Not preloaded by the score endpoints
+}
+
+// CandidateScoreHistory keeps a score that was replaced by re-scoring, with
+// the versions that produced it.
+type CandidateScoreHistory struct {
+	ID                    string     `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	CandidateScoreID      string     `gorm:"type:uuid;not null;index" json:"candidate_score_id"`
+	JobID                 string     `gorm:"not null;index:idx_candidate_score_history_job_resume" json:"job_id"`
+	ResumeID              string     `gorm:"not null;index:idx_candidate_score_history_job_resume" json:"resume_id"`
+	Score                 int        `gorm:"not null" json:"score"`
+	RequiredMatch         float64    `json:"required_match"`
+	NiceToHaveMatch       float64    `json:"nice_to_have_match"`
+	ExperienceMatch       float64    `json:"experience_match"`
+	EducationMatch        float64    `json:"education_match"`
+	AIEnhanced            bool       `json:"ai_enhanced"`
+	AIScore               float64    `json:"ai_score"`
+	ScoringMode           string     `json:"scoring_mode"`
+	Disqualified          bool       `json:"disqualified"`
+	ScoringProfileID      *string    `gorm:"type:uuid" json:"scoring_profile_id"`
+	ScoringProfileVersion int        `json:"scoring_profile_version"`
+	AlgorithmVersion      string     `json:"algorithm_version"`
+	AIModel               string     `json:"ai_model"`
+	ScoredAt              *time.Time `json:"scored_at"`
+	ArchivedAt            time.Time  `gorm:"autoCreateTime" json:"archived_at"`
+}
+
+func (CandidateScoreHistory) TableName() string {
+	return "candidate_score_history"
+}
+
+// SkillMatch explains how one job skill was matched: exact, alias, related
+// or missing, the resume skill it matched and the credit it earned.
+type SkillMatch struct {
+	Skill       string  `json:"skill"`
+	Kind        string  `json:"kind"`
+	MatchedWith string  `json:"matched_with,omitempty"`
+	Credit      float64 `json:"credit"`
+}
+
+// EmploymentGap is a break between two jobs on a resume. From and To are
+// inclusive months formatted as YYYY-MM.
+type EmploymentGap struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Months int    `json:"months"`
 }
@@ -2,19 +2,51 @@ package models
 
 import (
 	"time"
+
+	"gorm.io/gorm"
+)
+
+// Job statuses. Only open jobs are recommended to candidates and
+// auto-matched; closed and archived jobs cannot be matched at all.
+const (
+	JobStatusDraft    = "draft"
+	JobStatusOpen     = "open"
+	JobStatusPaused   = "paused"
+	JobStatusClosed   = "closed"
+	JobStatusArchived = "archived"
 )
 
 type JobDescription struct {
-	ID                string   `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
-	Title             string   `gorm:"not null" json:"title"`
-	Description       string   `gorm:"type:text" json:"description"`
-	RequiredSkills    []string `gorm:"type:text[]" json:"required_skills"`
-	NiceToHaveSkills  []string `gorm:"type:text[]" json:"nice_to_have_skills"`
-	ExperienceLevel   string   `gorm:"not null" json:"experience_level"` // e.g., "entry", "mid", "senior"
-	MinExperience     int      `gorm:"default:0" json:"min_experience"`   // in years
-	EducationRequired string   `gorm:"null" json:"education_required"`
-	Location          string   `gorm:"null" json:"location"`
-	SalaryRange       string   `gorm:"null" json:"salary_range"`
-	CreatedAt         time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt         time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	ID                string         `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	OrganizationID    *string        `gorm:"type:uuid;index" json:"organization_id"`
+	Title             string         `gorm:"not null" json:"title"`
+	Description       string         `gorm:"type:text" json:"description"`
+	RequiredSkills    []string       `gorm:"type:text[]" json:"required_skills"`
+	NiceToHaveSkills  []string       `gorm:"type:text[]" json:"nice_to_have_skills"`
+	ExperienceLevel   string         `gorm:"not null" json:"experience_level"` // e.g., "entry", "mid", "senior"
+	MinExperience     int            `gorm:"default:0" json:"min_experience"`  // in years
+	EducationRequired string         `gorm:"null" json:"education_required"`
+	Location          string         `gorm:"null" json:"location"`
+	SalaryRange       string         `gorm:"null" json:"salary_range"`
+	Status            string         `gorm:"not null;default:'open';index" json:"status"`      // draft, open, paused, closed or archived
+	ScoringProfileID  *string        `gorm:"type:uuid" json:"scoring_profile_id"`              // Falls back to the default profile when empty
+	AutoMatch         bool           `gorm:"default:false" json:"auto_match"`                  // Score the resume pool in the background when the job is created or updated
+	CreatedBy         *string        `gorm:"type:uuid" json:"created_by"`                      // User notified about auto-match results
+	KnockoutRules     []KnockoutRule `gorm:"type:jsonb;serializer:json" json:"knockout_rules"` // Must-have rules checked before scoring
+	CreatedAt         time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt         time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Relations
+	ScoringProfile *ScoringProfile `gorm:"foreignKey:ScoringProfileID" json:"scoring_profile,omitempty"`
+}
+
+// KnockoutRule is a must-have requirement. Which fields are used depends on
+// Type; see the Knockout* constants in the services package.
+type KnockoutRule struct {
+	Type        string   `json:"type"`
+	Value       string   `json:"value,omitempty"`
+	Values      []string `json:"values,omitempty"`
+	Years       int      `json:"years,omitempty"`
+	AllowRemote bool     `json:"allow_remote,omitempty"`
 }
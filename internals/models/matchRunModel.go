@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+)
+
+// Match run statuses.
+const (
+	MatchRunQueued    = "queued"
+	MatchRunRunning   = "running"
+	MatchRunCompleted = "completed"
+	MatchRunFailed    = "failed"
+	MatchRunCancelled = "cancelled"
+)
+
+// MatchRun is a queued or running bulk match of every resume against a job.
+// The table doubles as the work queue: workers claim queued rows with
+// SELECT ... FOR UPDATE SKIP LOCKED.
+type MatchRun struct {
+	ID              string         `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	JobID           string         `gorm:"not null;index" json:"job_id"`
+	OrganizationID  *string        `gorm:"type:uuid;index" json:"organization_id"`
+	Mode            string         `gorm:"not null" json:"mode"`
+	Full            bool           `gorm:"default:false" json:"full"`       // Re-score every resume, not only new or changed ones
+	NotifyTopN      int            `gorm:"default:0" json:"notify_top_n"`   // Send a notification with this many top candidates when done
+	NotifyUserID    *string        `gorm:"type:uuid" json:"notify_user_id"` // Recipient of that notification
+	Status          string         `gorm:"not null;index" json:"status"`
+	Total           int            `gorm:"default:0" json:"total"`        // Resumes to score
+	Scored          int            `gorm:"default:0" json:"scored"`       // Scores saved so far
+	Disqualified    int            `gorm:"default:0" json:"disqualified"` // Saved scores that failed a knockout rule
+	AIFallbacks     int            `gorm:"default:0" json:"ai_fallbacks"` // Saved scores that fell back to heuristic
+	Failed          int            `gorm:"default:0" json:"failed"`       // Resumes that could not be scored or saved
+	Failures        []MatchFailure `gorm:"type:jsonb;serializer:json" json:"failures"`
+	Error           string         `gorm:"type:text" json:"error,omitempty"` // Why the run as a whole failed
+	CancelRequested bool           `gorm:"default:false" json:"cancel_requested"`
+	WorkerID        string         `gorm:"null" json:"worker_id,omitempty"`
+	StartedAt       *time.Time     `json:"started_at"`
+	FinishedAt      *time.Time     `json:"finished_at"`
+	HeartbeatAt     *time.Time     `json:"heartbeat_at,omitempty"` // Last progress update from the worker
+	CreatedAt       time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// MatchFailure is a resume that could not be scored or saved.
+type MatchFailure struct {
+	ResumeID string `json:"resume_id"`
+	Error    string `json:"error"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+)
+
+const NotificationAutoMatch = "auto_match"
+
+// Notification tells a user about something that happened in the
+// background, such as the top candidates found by an auto-match run.
+type Notification struct {
+	ID         string              `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	UserID     *string             `gorm:"type:uuid;index" json:"user_id"` // Empty for jobs created without a signed-in user
+	Type       string              `gorm:"not null" json:"type"`
+	JobID      string              `gorm:"type:uuid;index" json:"job_id"`
+	MatchRunID string              `gorm:"type:uuid" json:"match_run_id"`
+	Title      string              `gorm:"not null" json:"title"`
+	Message    string              `gorm:"type:text" json:"message"`
+	Candidates []NotifiedCandidate `gorm:"type:jsonb;serializer:json" json:"candidates"`
+	ReadAt     *time.Time          `json:"read_at"`
+	CreatedAt  time.Time           `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// NotifiedCandidate is a candidate listed in a notification.
+type NotifiedCandidate struct {
+	ResumeID      string `json:"resume_id"`
+	CandidateName string `json:"candidate_name"`
+	Email         string `json:"email"`
+	Score         int    `json:"score"`
+}
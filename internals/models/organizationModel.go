@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+)
+
+// Organization is a tenant, such as one client company. Jobs, resumes and
+// scores belong to exactly one organization and are never shown to another.
+type Organization struct {
+	ID        string               `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	Name      string               `gorm:"not null" json:"name"`
+	Settings  OrganizationSettings `gorm:"type:jsonb;serializer:json" json:"settings"`
+	CreatedAt time.Time            `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time            `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// OrganizationSettings override server defaults for one organization.
+type OrganizationSettings struct {
+	DefaultScoringProfileID *string `json:"default_scoring_profile_id"` // Used by jobs without their own profile
+	AIProvider              string  `json:"ai_provider"`                // Empty for the server default, "none" to score heuristically only
+	AIModel                 string  `json:"ai_model"`
+	RetentionDays           int     `json:"retention_days"` // Resumes older than this are deleted, 0 keeps them
+}
+
+// OrganizationMember gives a user a role in an organization.
+type OrganizationMember struct {
+	ID             string    `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	OrganizationID string    `gorm:"type:uuid;not null;uniqueIndex:idx_organization_members_org_user,priority:1" json:"organization_id"`
+	UserID         string    `gorm:"type:uuid;not null;uniqueIndex:idx_organization_members_org_user,priority:2;index" json:"user_id"`
+	Role           string    `gorm:"not null" json:"role"` // admin, recruiter, hiring_manager or viewer
+	CreatedAt      time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// OrganizationInvite lets the user with Email join an organization. Only a
+// hash of the invite token is stored.
+type OrganizationInvite struct {
+	ID             string     `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	OrganizationID string     `gorm:"type:uuid;not null;index" json:"organization_id"`
+	Email          string     `gorm:"not null" json:"email"`
+	Role           string     `gorm:"not null" json:"role"`
+	TokenHash      string     `gorm:"not null;uniqueIndex" json:"-"`
+	InvitedBy      *string    `gorm:"type:uuid" json:"invited_by"`
+	ExpiresAt      time.Time  `gorm:"not null" json:"expires_at"`
+	AcceptedAt     *time.Time `json:"accepted_at"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
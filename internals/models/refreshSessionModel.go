@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+)
+
+// RefreshSession is one login of a user, such as one device. Its ID is the
+// sid claim of the session's tokens, and TokenID is the jti of the only
+// refresh token that may still be exchanged. Each refresh replaces TokenID;
+// presenting an older refresh token of the session ends the session.
+type RefreshSession struct {
+	ID        string    `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID    string    `gorm:"type:uuid;not null;index" json:"user_id"`
+	TokenID   string    `gorm:"not null" json:"-"`
+	ExpiresAt time.Time `gorm:"not null;index" json:"expires_at"` // When the current refresh token expires
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
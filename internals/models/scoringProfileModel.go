@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+)
+
+// ScoringProfile holds the weights used to score candidates for a job. The
+// four heuristic weights must sum to 1. AIWeight is the share of the AI score
+// in hybrid mode. Version is bumped on every edit and recorded on each score.
+// Profiles without an organization, like the seeded default, are global:
+// every organization can use them but only platform admins can edit them.
+type ScoringProfile struct {
+	ID               string    `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	OrganizationID   *string   `gorm:"type:uuid;uniqueIndex:idx_scoring_profiles_organization_name" json:"organization_id"`
+	Name             string    `gorm:"uniqueIndex:idx_scoring_profiles_organization_name;not null" json:"name"`
+	Description      string    `gorm:"type:text" json:"description"`
+	RequiredWeight   float64   `gorm:"not null" json:"required_weight"`
+	NiceToHaveWeight float64   `gorm:"not null" json:"nice_to_have_weight"`
+	ExperienceWeight float64   `gorm:"not null" json:"experience_weight"`
+	EducationWeight  float64   `gorm:"not null" json:"education_weight"`
+	AIWeight         float64   `gorm:"not null" json:"ai_weight"`
+	Version          int       `gorm:"not null;default:1" json:"version"`
+	CreatedAt        time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt        time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// SharedAcrossOrganizations makes global profiles visible to scoped reads.
+func (ScoringProfile) SharedAcrossOrganizations() {}
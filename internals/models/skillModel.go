@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+)
+
+// Skill is an entry in the admin-managed skill dictionary.
+type Skill struct {
+	ID            string    `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	Name          string    `gorm:"uniqueIndex;not null" json:"name"`
+	Category      string    `gorm:"null" json:"category"`
+	Aliases       []string  `gorm:"type:text[]" json:"aliases"`
+	CaseSensitive bool      `gorm:"default:false" json:"case_sensitive"` // Only match the exact casing of Name in text
+	Deprecated    bool      `gorm:"default:false" json:"deprecated"`     // No longer extracted from resumes
+	Related       []string  `gorm:"type:text[]" json:"related"`          // Skills that earn partial credit for this one
+	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
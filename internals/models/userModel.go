@@ -4,19 +4,32 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// User roles. What each role may do is set by the permission matrix in the
+// services package. RolePending is the platform role of users who signed
+// up themselves and has no permissions.
+const (
+	RoleAdmin         = "admin"
+	RoleRecruiter     = "recruiter"
+	RoleHiringManager = "hiring_manager"
+	RoleViewer        = "viewer"
+	RolePending       = "pending"
+)
+
 type User struct {
-	ID           string `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
-	Name         string `gorm:"not null" json:"name"`
-	Email        string `gorm:"uniqueIndex;not null" json:"email"`
-	Password     string `gorm:"not null" json:"password"`
-	Phone        string `gorm:"not null" json:"phone"`
-	RefreshToken string `gorm:"null" json:"refresh_token"`
-	ImageUrl     string `gorm:"null" json:"image_url"`
+	ID       string `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	Name     string `gorm:"not null" json:"name"`
+	Email    string `gorm:"uniqueIndex;not null" json:"email"`
+	Password string `gorm:"not null" json:"password"`
+	Phone    string `gorm:"not null" json:"phone"`
+	Role     string `gorm:"not null;default:'pending';index" json:"role"` // Platform role, admin or pending
+	ImageUrl string `gorm:"null" json:"image_url"`
 }
 
 type LoginInput struct {
 	Email    string `gorm:"type:varchar(255);uniqueIndex;not null" json:"email"`
-	Password string `gorm:"type:varchar(255);not null" json:"-"`
+	Password string `gorm:"type:varchar(255);not null" json:"password"`
+
+	OrganizationID string `gorm:"-" json:"organization_id"` // Optional, defaults to the user's first organization
 }
 
 func HashPassword(password string) (string, error) {
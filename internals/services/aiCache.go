@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+	"github.com/redis/go-redis/v9"
+)
+
+// aiPromptVersion is part of every cache key. Bump it whenever a prompt or
+// the response schema changes so stale answers are not served.
+const aiPromptVersion = "v2"
+
+const (
+	aiCacheStatsKey   = "ai:cache:stats"
+	aiCacheIndexKey   = "ai:cache:index:"
+	defaultAICacheTTL = 24 * time.Hour
+)
+
+// AICacheStats are the hit/miss counters shared by every instance.
+type AICacheStats struct {
+	MatchHits     int64   `json:"match_hits"`
+	MatchMisses   int64   `json:"match_misses"`
+	ExtractHits   int64   `json:"extract_hits"`
+	ExtractMisses int64   `json:"extract_misses"`
+	HitRate       float64 `json:"hit_rate"`
+}
+
+// AICache stores AI match and extraction results in Redis, keyed by a hash
+// of the input texts, the model and the prompt version. Every entry is also
+// indexed under the hash of each input text so all answers for one resume
+// or one job can be purged together.
+type AICache struct {
+	redis *redis.Client
+	ttl   time.Duration
+}
+
+func NewAICache(client *redis.Client, ttl time.Duration) *AICache {
+	if ttl <= 0 {
+		ttl = defaultAICacheTTL
+	}
+	return &AICache{redis: client, ttl: ttl}
+}
+
+func contentHash(parts ...string) string {
+	h := sha256.New()
+	for _, part := range parts {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (c *AICache) matchKey(resumeText, jobText, model string) (string, []string) {
+	resumeHash := contentHash(resumeText)
+	jobHash := contentHash(jobText)
+	key := "ai:match:" + contentHash(model, aiPromptVersion, resumeHash, jobHash)
+	return key, []string{resumeHash, jobHash}
+}
+
+func (c *AICache) extractKey(text, model string) (string, []string) {
+	textHash := contentHash(text)
+	key := "ai:extract:" + contentHash(model, aiPromptVersion, textHash)
+	return key, []string{textHash}
+}
+
+// get loads a cached value into out and records a hit or miss for op.
+func (c *AICache) get(ctx context.Context, op aiOperation, key string, out interface{}) bool {
+	data, err := c.redis.Get(ctx, key).Bytes()
+	hit := err == nil && json.Unmarshal(data, out) == nil
+
+	field := string(op) + "_misses"
+	if hit {
+		field = string(op) + "_hits"
+	}
+	if err := c.redis.HIncrBy(ctx, aiCacheStatsKey, field, 1).Err(); err != nil {
+		log.Printf("Failed to record AI cache %s: %v", field, err)
+	}
+
+	return hit
+}
+
+func (c *AICache) set(ctx context.Context, key string, value interface{}, textHashes []string) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	pipe := c.redis.TxPipeline()
+	pipe.Set(ctx, key, data, c.ttl)
+	for _, hash := range textHashes {
+		pipe.SAdd(ctx, aiCacheIndexKey+hash, key)
+		pipe.Expire(ctx, aiCacheIndexKey+hash, c.ttl)
+	}
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// PurgeResume drops every cached answer computed from the resume's text.
+func (c *AICache) PurgeResume(ctx context.Context, resume *models.Resume) (int64, error) {
+	return c.purgeText(ctx, resume.ParsedText)
+}
+
+// PurgeJob drops every cached answer computed from the job's description.
+func (c *AICache) PurgeJob(ctx context.Context, job *models.JobDescription) (int64, error) {
+	return c.purgeText(ctx, jobMatchText(job))
+}
+
+func (c *AICache) purgeText(ctx context.Context, text string) (int64, error) {
+	indexKey := aiCacheIndexKey + contentHash(text)
+
+	keys, err := c.redis.SMembers(ctx, indexKey).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return 0, err
+	}
+
+	removed := int64(0)
+	if len(keys) > 0 {
+		if removed, err = c.redis.Del(ctx, keys...).Result(); err != nil {
+			return 0, err
+		}
+	}
+
+	return removed, c.redis.Del(ctx, indexKey).Err()
+}
+
+func (c *AICache) Stats(ctx context.Context) (AICacheStats, error) {
+	values, err := c.redis.HGetAll(ctx, aiCacheStatsKey).Result()
+	if err != nil {
+		return AICacheStats{}, err
+	}
+
+	count := func(field string) int64 {
+		n, _ := strconv.ParseInt(values[field], 10, 64)
+		return n
+	}
+
+	stats := AICacheStats{
+		MatchHits:     count("match_hits"),
+		MatchMisses:   count("match_misses"),
+		ExtractHits:   count("extract_hits"),
+		ExtractMisses: count("extract_misses"),
+	}
+	if total := stats.MatchHits + stats.MatchMisses + stats.ExtractHits + stats.ExtractMisses; total > 0 {
+		stats.HitRate = float64(stats.MatchHits+stats.ExtractHits) / float64(total)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestAICache(t *testing.T) (*AICache, *miniredis.Miniredis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewAICache(client, time.Hour), server
+}
+
+func TestAICacheKeys(t *testing.T) {
+	cache, _ := newTestAICache(t)
+
+	key, hashes := cache.matchKey("resume", "job", "model-a")
+	if len(hashes) != 2 || hashes[0] != contentHash("resume") || hashes[1] != contentHash("job") {
+		t.Errorf("match text hashes = %v", hashes)
+	}
+
+	different := map[string]string{
+		"model":  func() string { k, _ := cache.matchKey("resume", "job", "model-b"); return k }(),
+		"resume": func() string { k, _ := cache.matchKey("resume 2", "job", "model-a"); return k }(),
+		"job":    func() string { k, _ := cache.matchKey("resume", "job 2", "model-a"); return k }(),
+		"swap":   func() string { k, _ := cache.matchKey("job", "resume", "model-a"); return k }(),
+	}
+	for name, other := range different {
+		if other == key {
+			t.Errorf("changing the %s kept the match key", name)
+		}
+	}
+	if again, _ := cache.matchKey("resume", "job", "model-a"); again != key {
+		t.Error("the match key is not stable")
+	}
+
+	extract, hashes := cache.extractKey("resume", "model-a")
+	if extract == key || len(hashes) != 1 || hashes[0] != contentHash("resume") {
+		t.Errorf("extract key %q hashes %v", extract, hashes)
+	}
+	if want := "ai:extract:" + contentHash("model-a", aiPromptVersion, contentHash("resume")); extract != want {
+		t.Errorf("extract key = %q, want %q", extract, want)
+	}
+}
+
+func TestAICacheGetSetAndStats(t *testing.T) {
+	cache, server := newTestAICache(t)
+	ctx := context.Background()
+	key, hashes := cache.matchKey("resume", "job", "model")
+
+	var result AIMatchResult
+	if cache.get(ctx, aiOperationMatch, key, &result) {
+		t.Fatal("hit on an empty cache")
+	}
+	if err := cache.set(ctx, key, AIMatchResult{Score: 72}, hashes); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if !cache.get(ctx, aiOperationMatch, key, &result) || result.Score != 72 {
+		t.Fatalf("get = %+v", result)
+	}
+	if ttl := server.TTL(key); ttl != time.Hour {
+		t.Errorf("entry TTL = %v, want 1h", ttl)
+	}
+
+	stats, err := cache.Stats(ctx)
+	if err != nil {
+		t.Fatalf("Stats: %v", err)
+	}
+	if stats.MatchHits != 1 || stats.MatchMisses != 1 || stats.HitRate != 0.5 {
+		t.Errorf("stats = %+v", stats)
+	}
+}
+
+func TestAICachePurge(t *testing.T) {
+	cache, server := newTestAICache(t)
+	ctx := context.Background()
+	resume := &models.Resume{ParsedText: "resume"}
+	job := &models.JobDescription{Title: "Go developer"}
+	jobText := jobMatchText(job)
+
+	matchA, hashesA := cache.matchKey(resume.ParsedText, jobText, "model")
+	matchB, hashesB := cache.matchKey("another resume", jobText, "model")
+	extract, extractHashes := cache.extractKey(resume.ParsedText, "model")
+	for key, hashes := range map[string][]string{matchA: hashesA, matchB: hashesB, extract: extractHashes} {
+		if err := cache.set(ctx, key, AIMatchResult{}, hashes); err != nil {
+			t.Fatalf("set: %v", err)
+		}
+	}
+
+	removed, err := cache.PurgeResume(ctx, resume)
+	if err != nil || removed != 2 {
+		t.Fatalf("PurgeResume = %d, %v; want 2", removed, err)
+	}
+	if server.Exists(matchA) || server.Exists(extract) || !server.Exists(matchB) {
+		t.Error("PurgeResume removed the wrong entries")
+	}
+	if server.Exists(aiCacheIndexKey + contentHash(resume.ParsedText)) {
+		t.Error("PurgeResume kept the resume's index set")
+	}
+
+	// The job's index still lists the purged entry; deleting it again is
+	// harmless and only the live entry counts.
+	removed, err = cache.PurgeJob(ctx, job)
+	if err != nil || removed != 1 {
+		t.Fatalf("PurgeJob = %d, %v; want 1", removed, err)
+	}
+	if server.Exists(matchB) {
+		t.Error("PurgeJob kept the job's entry")
+	}
+
+	if removed, err := cache.PurgeJob(ctx, job); err != nil || removed != 0 {
+		t.Errorf("PurgeJob on an empty index = %d, %v", removed, err)
+	}
+}
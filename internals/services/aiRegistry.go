@@ -0,0 +1,98 @@
+package services
+
+import (
+	"log"
+	"sync"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/config"
+)
+
+// AIRegistry returns the AI service an organization's settings ask for.
+// Organizations without an AI provider of their own share the default
+// service; the others get a service per provider and model, built on first
+// use with the rest of the application config.
+type AIRegistry struct {
+	cfg           config.Config
+	base          *AIService // nil when AI is disabled
+	organizations *OrganizationService
+
+	mu       sync.Mutex
+	services map[string]*AIService // nil entries record providers that failed to build
+}
+
+func NewAIRegistry(cfg config.Config, base *AIService, organizations *OrganizationService) *AIRegistry {
+	return &AIRegistry{
+		cfg:           cfg,
+		base:          base,
+		organizations: organizations,
+		services:      make(map[string]*AIService),
+	}
+}
+
+// For returns the AI service for the organization, or nil when it scores
+// without AI.
+func (r *AIRegistry) For(organizationID *string) *AIService {
+	if organizationID == nil || r.organizations == nil {
+		return r.base
+	}
+
+	settings, err := r.organizations.Settings(*organizationID)
+	if err != nil {
+		log.Printf("Failed to load settings of organization %s, using the default AI provider: %v", *organizationID, err)
+		return r.base
+	}
+
+	switch settings.AIProvider {
+	case "", "fake":
+		// Settings saved before the fake provider was refused use the
+		// default.
+		return r.base
+	case AIProviderNone:
+		return nil
+	}
+	return r.service(settings.AIProvider, settings.AIModel)
+}
+
+func (r *AIRegistry) service(provider, model string) *AIService {
+	key := provider + "/" + model
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if service, ok := r.services[key]; ok {
+		return service
+	}
+
+	cfg := r.cfg
+	cfg.AIProvider = provider
+	cfg.AIModel = model
+	cfg.AIMatchProvider, cfg.AIMatchModel = "", ""
+	cfg.AIExtractProvider, cfg.AIExtractModel = "", ""
+	cfg.AISummaryProvider, cfg.AISummaryModel = "", ""
+
+	var service *AIService
+	if cfg.AIEnabled() {
+		var err error
+		service, err = NewAIService(cfg)
+		if err != nil {
+			log.Printf("Warning: Failed to initialize AI provider %s, scoring without AI: %v", key, err)
+		} else if r.base != nil {
+			service.SetCache(r.base.Cache())
+		}
+	} else {
+		log.Printf("Warning: AI provider %s is not configured, scoring without AI", provider)
+	}
+
+	r.services[key] = service
+	return service
+}
+
+// Close releases the provider clients built for organizations.
+func (r *AIRegistry) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, service := range r.services {
+		if service != nil {
+			service.Close()
+		}
+	}
+}
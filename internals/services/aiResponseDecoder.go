@@ -0,0 +1,252 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	errNoJSONObject = errors.New("response contains no JSON object")
+	codeFence       = regexp.MustCompile("(?s)```[a-zA-Z]*\\s*(.*?)```")
+	leadingNumber   = regexp.MustCompile(`^-?\d+(?:\.\d+)?`)
+)
+
+type jsonFieldKind int
+
+const (
+	jsonNumber jsonFieldKind = iota
+	jsonInteger
+	jsonString
+	jsonStringList
+)
+
+// jsonField describes one property of a structured AI response. Numbers
+// are clamped to [min, max]; enum values are matched case-insensitively and
+// dropped if unknown.
+type jsonField struct {
+	name     string
+	kind     jsonFieldKind
+	required bool
+	min      float64
+	max      float64
+	enum     []string
+	example  string
+}
+
+type jsonSchema struct {
+	fields []jsonField
+}
+
+var aiMatchSchema = jsonSchema{fields: []jsonField{
+	{name: "score", kind: jsonNumber, required: true, min: 0, max: 100, example: "<number 0-100>"},
+	{name: "reasoning", kind: jsonString, required: true, example: `"<detailed explanation>"`},
+	{name: "matched_skills", kind: jsonStringList, example: `["skill1", "skill2"]`},
+	{name: "missing_skills", kind: jsonStringList, example: `["skill3", "skill4"]`},
+	{name: "experience_match", kind: jsonNumber, min: 0, max: 100, example: "<number 0-100>"},
+	{name: "education_match", kind: jsonNumber, min: 0, max: 100, example: "<number 0-100>"},
+}}
+
+var aiSkillExtractionSchema = jsonSchema{fields: []jsonField{
+	{name: "skills", kind: jsonStringList, required: true, example: `["skill1", "skill2", "skill3"]`},
+	{name: "experience_years", kind: jsonInteger, min: 0, max: 60, example: "<number>"},
+	{name: "education_level", kind: jsonString, enum: []string{"high_school", "associate", "bachelor", "master", "phd"}, example: `"<bachelor|master|phd|associate|high_school>"`},
+}}
+
+// SchemaError lists every problem found in a structured AI response. Its
+// message is fed back to the model when asking for a repaired reply.
+type SchemaError struct {
+	Problems []string
+}
+
+func (e *SchemaError) Error() string {
+	return "invalid AI response: " + strings.Join(e.Problems, "; ")
+}
+
+// describe renders the schema the same way the prompts spell it out.
+func (s jsonSchema) describe() string {
+	var b strings.Builder
+	b.WriteString("{\n")
+	for i, f := range s.fields {
+		fmt.Fprintf(&b, "  %q: %s", f.name, f.example)
+		if i < len(s.fields)-1 {
+			b.WriteString(",")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// decode pulls the first JSON object out of an AI reply, checks it against
+// the schema, normalizes field values and unmarshals the result into out.
+func (s jsonSchema) decode(response string, out interface{}) error {
+	raw, err := extractJSONObject(stripCodeFences(response))
+	if err != nil {
+		return &SchemaError{Problems: []string{err.Error()}}
+	}
+
+	var object map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &object); err != nil {
+		return &SchemaError{Problems: []string{"malformed JSON: " + err.Error()}}
+	}
+
+	clean := make(map[string]interface{})
+	var problems []string
+	for _, field := range s.fields {
+		value, present := object[field.name]
+		if !present || value == nil {
+			if field.required {
+				problems = append(problems, fmt.Sprintf("missing %q", field.name))
+			}
+			continue
+		}
+
+		normalized, err := field.normalize(value)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%q %s", field.name, err.Error()))
+			continue
+		}
+		if normalized != nil {
+			clean[field.name] = normalized
+		}
+	}
+
+	if len(problems) > 0 {
+		return &SchemaError{Problems: problems}
+	}
+
+	encoded, err := json.Marshal(clean)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(encoded, out)
+}
+
+func (f jsonField) normalize(value interface{}) (interface{}, error) {
+	switch f.kind {
+	case jsonNumber, jsonInteger:
+		n, err := toNumber(value)
+		if err != nil {
+			return nil, err
+		}
+		n = math.Max(f.min, math.Min(f.max, n))
+		if f.kind == jsonInteger {
+			return int(math.Round(n)), nil
+		}
+		return n, nil
+
+	case jsonString:
+		str, ok := value.(string)
+		if !ok {
+			return nil, errors.New("must be a string")
+		}
+		str = strings.TrimSpace(str)
+		if len(f.enum) == 0 {
+			return str, nil
+		}
+		key := strings.NewReplacer(".", "", "'", "", " ", "_").Replace(strings.ToLower(str))
+		for _, allowed := range f.enum {
+			if key == allowed || strings.TrimSuffix(key, "s") == allowed {
+				return allowed, nil
+			}
+		}
+		return nil, nil
+
+	case jsonStringList:
+		var items []interface{}
+		switch v := value.(type) {
+		case []interface{}:
+			items = v
+		case string:
+			// Some models return "Go, Docker" instead of a list.
+			for _, part := range strings.Split(v, ",") {
+				items = append(items, part)
+			}
+		default:
+			return nil, errors.New("must be a list of strings")
+		}
+
+		list := make([]string, 0, len(items))
+		for _, item := range items {
+			str, ok := item.(string)
+			if !ok {
+				return nil, errors.New("must be a list of strings")
+			}
+			if str = strings.TrimSpace(str); str != "" {
+				list = append(list, str)
+			}
+		}
+		return list, nil
+	}
+
+	return nil, errors.New("unsupported field type")
+}
+
+// toNumber accepts JSON numbers and numeric strings such as "85" or "85%".
+func toNumber(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case string:
+		if match := leadingNumber.FindString(strings.TrimSpace(v)); match != "" {
+			return strconv.ParseFloat(match, 64)
+		}
+	}
+	return 0, errors.New("must be a number")
+}
+
+// stripCodeFences returns the contents of the first ``` fenced block, or
+// the input unchanged if it has none.
+func stripCodeFences(response string) string {
+	if match := codeFence.FindStringSubmatch(response); match != nil {
+		return match[1]
+	}
+	return response
+}
+
+// extractJSONObject returns the first balanced {...} in text. Braces inside
+// JSON strings are ignored.
+func extractJSONObject(text string) (string, error) {
+	start := strings.Index(text, "{")
+	if start < 0 {
+		return "", errNoJSONObject
+	}
+
+	depth := 0
+	inString := false
+	escaped := false
+	for i := start; i < len(text); i++ {
+		c := text[i]
+
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
+		}
+
+		switch c {
+		case '"':
+			inString = true
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return text[start : i+1], nil
+			}
+		}
+	}
+
+	return "", errors.New("response contains an unterminated JSON object")
+}
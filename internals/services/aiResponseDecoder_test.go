@@ -0,0 +1,89 @@
+package services
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDecodeMatchReply(t *testing.T) {
+	reply := "Here is the result:\n```json\n" +
+		`{"score": "130%", "reasoning": " Uses {braces} and \"quotes\" ", "matched_skills": "Go, Docker,", "experience_match": -5}` +
+		"\n```\nHope this helps."
+
+	var result AIMatchResult
+	if err := aiMatchSchema.decode(reply, &result); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if result.Score != 100 {
+		t.Errorf("score = %v, want 100", result.Score)
+	}
+	if result.ExperienceMatch != 0 {
+		t.Errorf("experience_match = %v, want 0", result.ExperienceMatch)
+	}
+	if result.Reasoning != `Uses {braces} and "quotes"` {
+		t.Errorf("reasoning = %q", result.Reasoning)
+	}
+	if !slices.Equal(result.MatchedSkills, []string{"Go", "Docker"}) {
+		t.Errorf("matched_skills = %v", result.MatchedSkills)
+	}
+}
+
+func TestDecodeSkillExtractionReply(t *testing.T) {
+	reply := `Sure! {"skills": ["Go", " ", "SQL"], "experience_years": 4.6, "education_level": "Bachelor's"} Anything else?`
+
+	var result AISkillExtraction
+	if err := aiSkillExtractionSchema.decode(reply, &result); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !slices.Equal(result.Skills, []string{"Go", "SQL"}) {
+		t.Errorf("skills = %v", result.Skills)
+	}
+	if result.ExperienceYears != 5 {
+		t.Errorf("experience_years = %d, want 5", result.ExperienceYears)
+	}
+	if result.EducationLevel != "bachelor" {
+		t.Errorf("education_level = %q, want bachelor", result.EducationLevel)
+	}
+
+	var unknown AISkillExtraction
+	if err := aiSkillExtractionSchema.decode(`{"skills": [], "education_level": "wizard"}`, &unknown); err != nil {
+		t.Fatalf("decode unknown education level: %v", err)
+	}
+	if unknown.EducationLevel != "" {
+		t.Errorf("unknown education level decoded as %q", unknown.EducationLevel)
+	}
+}
+
+func TestDecodeReportsProblems(t *testing.T) {
+	tests := []struct {
+		reply    string
+		problems []string
+	}{
+		{"no JSON here", []string{"no JSON object"}},
+		{`{"score": 50, "reasoning": "cut off`, []string{"unterminated"}},
+		{`{"score": 50,, "reasoning": "x"}`, []string{"malformed JSON"}},
+		{`{"matched_skills": [1, 2]}`, []string{`missing "score"`, `missing "reasoning"`, `"matched_skills" must be a list of strings`}},
+		{`{"score": "high", "reasoning": 7}`, []string{`"score" must be a number`, `"reasoning" must be a string`}},
+	}
+	for _, tt := range tests {
+		var result AIMatchResult
+		err := aiMatchSchema.decode(tt.reply, &result)
+
+		var schemaErr *SchemaError
+		if !errors.As(err, &schemaErr) {
+			t.Errorf("decode(%q) = %v, want a SchemaError", tt.reply, err)
+			continue
+		}
+		if len(schemaErr.Problems) != len(tt.problems) {
+			t.Errorf("decode(%q) problems = %q, want %d", tt.reply, schemaErr.Problems, len(tt.problems))
+			continue
+		}
+		for i, want := range tt.problems {
+			if !strings.Contains(schemaErr.Problems[i], want) {
+				t.Errorf("decode(%q) problem %d = %q, want it to mention %q", tt.reply, i, schemaErr.Problems[i], want)
+			}
+		}
+	}
+}
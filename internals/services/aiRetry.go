@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"time"
+)
+
+const (
+	aiRetryBaseDelay = 500 * time.Millisecond
+	aiRetryMaxDelay  = 8 * time.Second
+)
+
+// isRetryableAIError reports whether a failed provider call is worth
+// repeating: rate limits, server errors, timeouts and dropped connections.
+// Client errors such as a bad API key are returned straight away.
+func isRetryableAIError(err error) bool {
+	var providerErr *ProviderError
+	if errors.As(err, &providerErr) {
+		return providerErr.StatusCode == http.StatusTooManyRequests ||
+			providerErr.StatusCode == http.StatusRequestTimeout ||
+			providerErr.StatusCode >= 500
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF)
+}
+
+// retryDelay is the exponential backoff before retry attempt n (1-based),
+// with up to 50% jitter so parallel workers don't retry in lockstep.
+func retryDelay(attempt int) time.Duration {
+	delay := aiRetryBaseDelay << (attempt - 1)
+	if delay > aiRetryMaxDelay || delay <= 0 {
+		delay = aiRetryMaxDelay
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// sleepContext waits for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
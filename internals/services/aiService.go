@@ -2,18 +2,58 @@ package services
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
-	"github.com/google/generative-ai-go/genai"
-	"google.golang.org/api/option"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/config"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/taxonomy"
+	"golang.org/x/time/rate"
+)
+
+// aiOperation names the kinds of calls AIService makes, each of which can be
+// routed to its own provider and model.
+type aiOperation string
+
+const (
+	aiOperationMatch   aiOperation = "match"
+	aiOperationExtract aiOperation = "extract"
+	aiOperationSummary aiOperation = "summary"
+)
+
+type aiRoute struct {
+	provider LLMProvider
+	model    string
+	breaker  *circuitBreaker
+}
+
+// Defaults for services built without a config.
+const (
+	defaultRepairAttempts   = 2
+	defaultAICallTimeout    = 30 * time.Second
+	defaultAIMaxRetries     = 2
+	defaultBreakerThreshold = 5
+	defaultBreakerCooldown  = 30 * time.Second
 )
 
 type AIService struct {
-	client *genai.Client
+	routes            map[aiOperation]aiRoute
+	providers         []LLMProvider
+	breakers          []*circuitBreaker
+	maxRepairAttempts int
+	callTimeout       time.Duration
+	maxRetries        int
+	limiter           *rate.Limiter
+	cache             *AICache
+}
+
+var manualScorePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)"?(?:overall\s+|match\s+)?score"?\s*[:=]?\s*(\d+(?:\.\d+)?)`),
+	regexp.MustCompile(`(\d+(?:\.\d+)?)\s*(?:/\s*100|%)`),
 }
 
 type AIMatchResult struct {
@@ -26,28 +66,232 @@ type AIMatchResult struct {
 }
 
 type AISkillExtraction struct {
-	Skills         []string `json:"skills"`
+	Skills          []string `json:"skills"`
 	ExperienceYears int      `json:"experience_years"`
 	EducationLevel  string   `json:"education_level"`
 }
 
-func NewAIService(apiKey string) (*AIService, error) {
-	ctx := context.Background()
-	client, err := genai.NewClient(ctx, option.WithAPIKey(apiKey))
-	if err != nil {
-		return nil, err
+// NewAIService builds the providers named in the config. Operations without
+// their own AI_<OP>_PROVIDER / AI_<OP>_MODEL use AI_PROVIDER / AI_MODEL.
+func NewAIService(cfg config.Config) (*AIService, error) {
+	service := &AIService{
+		routes:            make(map[aiOperation]aiRoute),
+		maxRepairAttempts: cfg.AIMaxRepairAttempts,
+		callTimeout:       cfg.AITimeout,
+		maxRetries:        cfg.AIMaxRetries,
+	}
+	if cfg.AIRateLimit > 0 {
+		service.limiter = rate.NewLimiter(rate.Every(time.Minute/time.Duration(cfg.AIRateLimit)), 1)
+	}
+	byName := make(map[string]LLMProvider)
+	breakers := make(map[string]*circuitBreaker)
+
+	operations := []struct {
+		op       aiOperation
+		provider string
+		model    string
+	}{
+		{aiOperationMatch, cfg.AIMatchProvider, cfg.AIMatchModel},
+		{aiOperationExtract, cfg.AIExtractProvider, cfg.AIExtractModel},
+		{aiOperationSummary, cfg.AISummaryProvider, cfg.AISummaryModel},
+	}
+
+	for _, o := range operations {
+		name := firstNonEmpty(o.provider, cfg.AIProvider, "gemini")
+
+		provider, ok := byName[name]
+		if !ok {
+			var err error
+			provider, err = NewLLMProvider(name, cfg)
+			if err != nil {
+				service.Close()
+				return nil, err
+			}
+			byName[name] = provider
+			breakers[name] = newCircuitBreaker(name, cfg.AIBreakerThreshold, cfg.AIBreakerCooldown)
+			service.providers = append(service.providers, provider)
+			service.breakers = append(service.breakers, breakers[name])
+		}
+
+		model := o.model
+		if model == "" && o.provider == "" {
+			model = cfg.AIModel
+		}
+		service.routes[o.op] = aiRoute{
+			provider: provider,
+			model:    firstNonEmpty(model, defaultModels[name]),
+			breaker:  breakers[name],
+		}
 	}
 
-	return &AIService{client: client}, nil
+	return service, nil
+}
+
+// NewAIServiceWithProvider routes every operation to one provider and model.
+// It is mainly useful with FakeLLMProvider in tests.
+func NewAIServiceWithProvider(provider LLMProvider, model string) *AIService {
+	breaker := newCircuitBreaker(provider.Name(), defaultBreakerThreshold, defaultBreakerCooldown)
+	route := aiRoute{provider: provider, model: model, breaker: breaker}
+	return &AIService{
+		routes: map[aiOperation]aiRoute{
+			aiOperationMatch:   route,
+			aiOperationExtract: route,
+			aiOperationSummary: route,
+		},
+		providers:         []LLMProvider{provider},
+		breakers:          []*circuitBreaker{breaker},
+		maxRepairAttempts: defaultRepairAttempts,
+		callTimeout:       defaultAICallTimeout,
+		maxRetries:        defaultAIMaxRetries,
+	}
 }
 
 func (a *AIService) Close() {
-	a.client.Close()
+	for _, provider := range a.providers {
+		provider.Close()
+	}
+}
+
+// SetCache puts a result cache in front of match and extraction calls.
+func (a *AIService) SetCache(cache *AICache) {
+	a.cache = cache
+}
+
+// Cache returns the result cache, or nil if caching is disabled.
+func (a *AIService) Cache() *AICache {
+	return a.cache
+}
+
+// modelName identifies the provider and model serving op, for cache keys.
+func (a *AIService) modelName(op aiOperation) string {
+	route := a.routes[op]
+	if route.provider == nil {
+		return route.model
+	}
+	return route.provider.Name() + "/" + route.model
+}
+
+// generateStructured asks for a JSON reply matching schema and decodes it
+// into out. A reply that fails validation is sent back to the model with the
+// problems listed, up to maxRepairAttempts times. The last raw reply is
+// returned so callers can fall back to heuristic parsing.
+func (a *AIService) generateStructured(ctx context.Context, op aiOperation, prompt string, schema jsonSchema, out interface{}) (string, error) {
+	response, err := a.generate(ctx, op, prompt, true)
+	if err != nil {
+		return "", err
+	}
+
+	decodeErr := schema.decode(response, out)
+	for attempt := 0; decodeErr != nil && attempt < a.maxRepairAttempts; attempt++ {
+		repaired, err := a.generate(ctx, op, repairPrompt(prompt, response, decodeErr, schema), true)
+		if err != nil {
+			return response, err
+		}
+		response = repaired
+		decodeErr = schema.decode(response, out)
+	}
+
+	return response, decodeErr
+}
+
+func repairPrompt(prompt, response string, problem error, schema jsonSchema) string {
+	const maxEcho = 2000
+	if len(response) > maxEcho {
+		response = response[:maxEcho]
+	}
+
+	return fmt.Sprintf(`%s
+
+Your previous reply could not be used (%s).
+Previous reply:
+%s
+
+Reply again with only a single JSON object, no markdown and no commentary, in exactly this structure:
+%s`, prompt, problem.Error(), response, schema.describe())
+}
+
+// BreakerStates reports the circuit breaker of every configured provider.
+func (a *AIService) BreakerStates() []BreakerState {
+	states := make([]BreakerState, 0, len(a.breakers))
+	for _, breaker := range a.breakers {
+		states = append(states, breaker.Snapshot())
+	}
+	return states
 }
 
-func (a *AIService) EnhanceMatching(resumeText, jobDescription string) (*AIMatchResult, error) {
-	ctx := context.Background()
-	model := a.client.GenerativeModel("gemini-1.5-flash")
+// generate sends a prompt to the provider and model configured for op. Each
+// attempt gets its own deadline, retryable failures are retried with
+// exponential backoff, and the provider's circuit breaker short-circuits
+// calls while the provider is failing. A caller giving up (ctx cancelled)
+// is not held against the provider.
+func (a *AIService) generate(ctx context.Context, op aiOperation, prompt string, jsonOutput bool) (string, error) {
+	route, ok := a.routes[op]
+	if !ok {
+		return "", fmt.Errorf("no AI provider configured for %s", op)
+	}
+
+	if err := route.breaker.Allow(); err != nil {
+		return "", err
+	}
+
+	req := LLMRequest{
+		Model:  route.model,
+		Prompt: prompt,
+		JSON:   jsonOutput,
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= a.maxRetries; attempt++ {
+		if attempt > 0 {
+			if err := sleepContext(ctx, retryDelay(attempt)); err != nil {
+				lastErr = err
+				break
+			}
+		}
+
+		// Wait for the shared rate limit so bulk matching paces itself
+		// instead of running into provider 429s.
+		if a.limiter != nil {
+			if err := a.limiter.Wait(ctx); err != nil {
+				route.breaker.Abandon()
+				return "", err
+			}
+		}
+
+		callCtx, cancel := context.WithTimeout(ctx, a.callTimeout)
+		text, err := route.provider.Generate(callCtx, req)
+		cancel()
+
+		if err == nil {
+			route.breaker.Success()
+			return text, nil
+		}
+		lastErr = err
+
+		if ctx.Err() != nil || !isRetryableAIError(err) {
+			break
+		}
+	}
+
+	if ctx.Err() != nil {
+		// Release a half-open probe without counting it as a failure.
+		route.breaker.Abandon()
+		return "", ctx.Err()
+	}
+	route.breaker.Failure(lastErr)
+	return "", lastErr
+}
+
+func (a *AIService) EnhanceMatching(ctx context.Context, resumeText, jobDescription string) (*AIMatchResult, error) {
+	var cacheKey string
+	var textHashes []string
+	if a.cache != nil {
+		cacheKey, textHashes = a.cache.matchKey(resumeText, jobDescription, a.modelName(aiOperationMatch))
+		var cached AIMatchResult
+		if a.cache.get(ctx, aiOperationMatch, cacheKey, &cached) {
+			return &cached, nil
+		}
+	}
 
 	prompt := fmt.Sprintf(`Analyze the following resume and job description for comprehensive matching.
 Return a JSON response with the following structure:
@@ -73,48 +317,68 @@ Focus on:
 - Cultural fit indicators
 - Overall suitability`, resumeText, jobDescription)
 
-	resp, err := model.GenerateContent(ctx, genai.Text(prompt))
+	var result AIMatchResult
+	responseText, err := a.generateStructured(ctx, aiOperationMatch, prompt, aiMatchSchema, &result)
+	var schemaErr *SchemaError
+	if errors.As(err, &schemaErr) {
+		// Still unusable after repairs, extract score and reasoning manually
+		result, ok := a.parseManualResponse(responseText)
+		if !ok {
+			return nil, fmt.Errorf("AI reply has no score: %w", schemaErr)
+		}
+		return result, nil
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	if len(resp.Candidates) == 0 {
-		return nil, fmt.Errorf("no response from AI")
-	}
-
-	responseText := fmt.Sprintf("%v", resp.Candidates[0].Content.Parts[0])
-
-	// Try to parse as JSON
-	var result AIMatchResult
-	if err := json.Unmarshal([]byte(responseText), &result); err != nil {
-		// If JSON parsing fails, extract score and reasoning manually
-		return a.parseManualResponse(responseText)
+	if a.cache != nil {
+		if err := a.cache.set(ctx, cacheKey, result, textHashes); err != nil {
+			log.Printf("Failed to cache AI match result: %v", err)
+		}
 	}
 
 	return &result, nil
 }
 
-func (a *AIService) parseManualResponse(response string) (*AIMatchResult, error) {
+// parseManualResponse reads the score and reasoning from a free-text reply.
+// It reports false when the reply has no score, so the caller does not
+// mistake a missing score for 0.
+func (a *AIService) parseManualResponse(response string) (*AIMatchResult, bool) {
 	result := &AIMatchResult{}
-
-	// Extract score using regex
-	scoreRegex := regexp.MustCompile(`(\d+(?:\.\d+)?)(?:/100|%)`)
-	if match := scoreRegex.FindStringSubmatch(response); len(match) > 1 {
-		if score, err := strconv.ParseFloat(match[1], 64); err == nil {
-			result.Score = score
+	found := false
+
+	// Prefer a number labelled as the score, then anything out of 100
+	for _, scoreRegex := range manualScorePatterns {
+		if match := scoreRegex.FindStringSubmatch(response); len(match) > 1 {
+			if score, err := strconv.ParseFloat(match[1], 64); err == nil && score <= 100 {
+				result.Score = score
+				found = true
+				break
+			}
 		}
 	}
+	if !found {
+		return nil, false
+	}
 
 	// Extract reasoning (everything after score)
 	parts := strings.Split(response, "\n")
 	result.Reasoning = strings.Join(parts[1:], "\n")
 
-	return result, nil
+	return result, true
 }
 
-func (a *AIService) ExtractSkillsFromText(text string) (*AISkillExtraction, error) {
-	ctx := context.Background()
-	model := a.client.GenerativeModel("gemini-1.5-flash")
+func (a *AIService) ExtractSkillsFromText(ctx context.Context, text string) (*AISkillExtraction, error) {
+	var cacheKey string
+	var textHashes []string
+	if a.cache != nil {
+		cacheKey, textHashes = a.cache.extractKey(text, a.modelName(aiOperationExtract))
+		var cached AISkillExtraction
+		if a.cache.get(ctx, aiOperationExtract, cacheKey, &cached) {
+			return &cached, nil
+		}
+	}
 
 	prompt := fmt.Sprintf(`Extract technical skills, experience level, and education from the following text.
 Return a JSON response with the following structure:
@@ -134,21 +398,21 @@ Focus on:
 - Years of experience
 - Highest education level`, text)
 
-	resp, err := model.GenerateContent(ctx, genai.Text(prompt))
+	var result AISkillExtraction
+	_, err := a.generateStructured(ctx, aiOperationExtract, prompt, aiSkillExtractionSchema, &result)
+	var schemaErr *SchemaError
+	if errors.As(err, &schemaErr) {
+		// Fallback to manual extraction
+		return a.manualSkillExtraction(text)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	if len(resp.Candidates) == 0 {
-		return nil, fmt.Errorf("no response from AI")
-	}
-
-	responseText := fmt.Sprintf("%v", resp.Candidates[0].Content.Parts[0])
-
-	var result AISkillExtraction
-	if err := json.Unmarshal([]byte(responseText), &result); err != nil {
-		// Fallback to manual extraction
-		return a.manualSkillExtraction(text)
+	if a.cache != nil {
+		if err := a.cache.set(ctx, cacheKey, result, textHashes); err != nil {
+			log.Printf("Failed to cache AI skill extraction: %v", err)
+		}
 	}
 
 	return &result, nil
@@ -157,21 +421,9 @@ Focus on:
 func (a *AIService) manualSkillExtraction(text string) (*AISkillExtraction, error) {
 	result := &AISkillExtraction{}
 
-	// Simple skill extraction based on common keywords
-	skillKeywords := []string{
-		"python", "java", "javascript", "go", "c++", "c#", "php", "ruby", "swift", "kotlin",
-		"react", "angular", "vue", "node", "django", "flask", "spring", "laravel", "express",
-		"mysql", "postgresql", "mongodb", "redis", "docker", "kubernetes", "aws", "azure", "gcp",
-		"git", "linux", "windows", "agile", "scrum", "ci/cd", "jenkins", "github", "gitlab",
-		"html", "css", "typescript", "graphql", "rest", "api", "microservices",
-	}
+	result.Skills = taxonomy.Default().Extract(text)
 
 	lowerText := strings.ToLower(text)
-	for _, skill := range skillKeywords {
-		if strings.Contains(lowerText, skill) {
-			result.Skills = append(result.Skills, strings.Title(skill))
-		}
-	}
 
 	// Extract experience years
 	expRegex := regexp.MustCompile(`(\d+)\s*(?:year|yr)s?\s*(?:of\s*)?experience`)
@@ -182,27 +434,12 @@ func (a *AIService) manualSkillExtraction(text string) (*AISkillExtraction, erro
 	}
 
 	// Extract education level
-	educationLevels := map[string]string{
-		"phd": "phd", "doctorate": "phd", "doctoral": "phd",
-		"master": "master", "masters": "master", "msc": "master", "ms": "master",
-		"bachelor": "bachelor", "bachelors": "bachelor", "bsc": "bachelor", "bs": "bachelor",
-		"associate": "associate", "diploma": "associate",
-	}
-
-	for _, level := range educationLevels {
-		if strings.Contains(lowerText, level) {
-			result.EducationLevel = level
-			break
-		}
-	}
+	result.EducationLevel = ParseEducationLevel(text).String()
 
 	return result, nil
 }
 
-func (a *AIService) GenerateJobSummary(jobDescription string) (string, error) {
-	ctx := context.Background()
-	model := a.client.GenerativeModel("gemini-1.5-flash")
-
+func (a *AIService) GenerateJobSummary(ctx context.Context, jobDescription string) (string, error) {
 	prompt := fmt.Sprintf(`Create a concise summary of the following job description, highlighting:
 - Key responsibilities
 - Required skills and qualifications
@@ -214,14 +451,14 @@ Job Description:
 
 Keep the summary under 200 words.`, jobDescription)
 
-	resp, err := model.GenerateContent(ctx, genai.Text(prompt))
-	if err != nil {
-		return "", err
-	}
+	return a.generate(ctx, aiOperationSummary, prompt, false)
+}
 
-	if len(resp.Candidates) == 0 {
-		return "", fmt.Errorf("no response from AI")
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
 	}
-
-	return fmt.Sprintf("%v", resp.Candidates[0].Content.Parts[0]), nil
+	return ""
 }
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+)
+
+const validMatchReply = `{"score": 82, "reasoning": "Strong Go background", "matched_skills": ["go"], "missing_skills": ["rust"], "experience_match": 90, "education_match": 70}`
+
+func TestEnhanceMatchingDecodesReply(t *testing.T) {
+	provider := NewFakeLLMProvider(validMatchReply)
+	service := NewAIServiceWithProvider(provider, "test-model")
+
+	result, err := service.EnhanceMatching(context.Background(), "resume", "job")
+	if err != nil {
+		t.Fatalf("EnhanceMatching: %v", err)
+	}
+	if result.Score != 82 || result.Reasoning != "Strong Go background" {
+		t.Errorf("got score %v reasoning %q", result.Score, result.Reasoning)
+	}
+	if len(result.MatchedSkills) != 1 || result.MatchedSkills[0] != "go" {
+		t.Errorf("matched skills = %v", result.MatchedSkills)
+	}
+
+	requests := provider.Requests()
+	if len(requests) != 1 {
+		t.Fatalf("got %d requests, want 1", len(requests))
+	}
+	if requests[0].Model != "test-model" || !requests[0].JSON {
+		t.Errorf("request model %q json %v", requests[0].Model, requests[0].JSON)
+	}
+}
+
+func TestEnhanceMatchingRepairsMalformedReply(t *testing.T) {
+	provider := NewFakeLLMProvider(`{"reasoning": "no score"}`, validMatchReply)
+	service := NewAIServiceWithProvider(provider, "test-model")
+
+	result, err := service.EnhanceMatching(context.Background(), "resume", "job")
+	if err != nil {
+		t.Fatalf("EnhanceMatching: %v", err)
+	}
+	if result.Score != 82 {
+		t.Errorf("score = %v, want 82", result.Score)
+	}
+
+	requests := provider.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("got %d requests, want 2", len(requests))
+	}
+	if !strings.Contains(requests[1].Prompt, "Your previous reply could not be used") {
+		t.Errorf("second prompt is not a repair prompt: %q", requests[1].Prompt)
+	}
+}
+
+func TestEnhanceMatchingFallsBackToManualParsing(t *testing.T) {
+	provider := NewFakeLLMProvider("Score: 64/100\nDecent fit")
+	service := NewAIServiceWithProvider(provider, "test-model")
+
+	result, err := service.EnhanceMatching(context.Background(), "resume", "job")
+	if err != nil {
+		t.Fatalf("EnhanceMatching: %v", err)
+	}
+	if result.Score != 64 {
+		t.Errorf("score = %v, want 64", result.Score)
+	}
+	if got := len(provider.Requests()); got != 1+defaultRepairAttempts {
+		t.Errorf("got %d requests, want %d", got, 1+defaultRepairAttempts)
+	}
+}
+
+func TestEnhanceMatchingRejectsReplyWithoutScore(t *testing.T) {
+	provider := NewFakeLLMProvider("I could not evaluate this candidate.")
+	service := NewAIServiceWithProvider(provider, "test-model")
+
+	result, err := service.EnhanceMatching(context.Background(), "resume", "job")
+	if err == nil {
+		t.Fatalf("EnhanceMatching = %+v, want an error", result)
+	}
+
+	var schemaErr *SchemaError
+	if !errors.As(err, &schemaErr) {
+		t.Errorf("error %v does not wrap the schema error", err)
+	}
+}
+
+func TestMatchKeepsHeuristicScoreWithoutAIScore(t *testing.T) {
+	service := NewAIServiceWithProvider(NewFakeLLMProvider("No idea."), "test-model")
+	matcher := NewJobMatcherService(service)
+	resume := &models.Resume{Skills: []string{"Go"}}
+	job := &models.JobDescription{RequiredSkills: []string{"Go"}}
+
+	heuristic := matcher.MatchResumeToJob(resume, job).Score
+	for _, mode := range []string{ScoringModeAI, ScoringModeHybrid} {
+		score := matcher.Match(context.Background(), mode, resume, job)
+		if score.AIEnhanced || score.ScoringMode != ScoringModeHeuristic || score.Score != heuristic {
+			t.Errorf("%s: ai_enhanced %v mode %q score %d, want the heuristic score %d", mode, score.AIEnhanced, score.ScoringMode, score.Score, heuristic)
+		}
+	}
+}
+
+func TestProviderFailuresOpenCircuitBreaker(t *testing.T) {
+	provider := NewFakeLLMProvider()
+	provider.FailWith(errors.New("bad request"))
+	service := NewAIServiceWithProvider(provider, "test-model")
+
+	for i := 0; i < defaultBreakerThreshold; i++ {
+		if _, err := service.EnhanceMatching(context.Background(), "resume", "job"); err == nil {
+			t.Fatal("expected an error from a failing provider")
+		}
+	}
+	// A non-retryable error is attempted once per call.
+	if got := len(provider.Requests()); got != defaultBreakerThreshold {
+		t.Fatalf("got %d requests, want %d", got, defaultBreakerThreshold)
+	}
+
+	_, err := service.EnhanceMatching(context.Background(), "resume", "job")
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("err = %v, want ErrCircuitOpen", err)
+	}
+	if got := len(provider.Requests()); got != defaultBreakerThreshold {
+		t.Errorf("open breaker still called the provider: %d requests", got)
+	}
+}
+
+func TestExtractSkillsFromText(t *testing.T) {
+	provider := NewFakeLLMProvider(`{"skills": ["Go", "PostgreSQL"], "experience_years": 6, "education_level": "Master"}`)
+	service := NewAIServiceWithProvider(provider, "test-model")
+
+	result, err := service.ExtractSkillsFromText(context.Background(), "text")
+	if err != nil {
+		t.Fatalf("ExtractSkillsFromText: %v", err)
+	}
+	if len(result.Skills) != 2 || result.ExperienceYears != 6 || result.EducationLevel != "master" {
+		t.Errorf("got %+v", result)
+	}
+}
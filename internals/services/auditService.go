@@ -0,0 +1,55 @@
+package services
+
+import (
+	"log"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultAuditPageSize = 50
+	maxAuditPageSize     = 500
+)
+
+// AuditService stores audit events.
+type AuditService struct {
+	db *gorm.DB
+}
+
+func NewAuditService(db *gorm.DB) *AuditService {
+	return &AuditService{db: db}
+}
+
+// Record logs the event and stores it. A failed write is only logged, so
+// auditing never changes the response a caller gets.
+func (s *AuditService) Record(event *models.AuditEvent) {
+	log.Printf("Audit: %s %s %s user=%s role=%s permission=%s: %s",
+		event.Type, event.Method, event.Path, event.Email, event.Role, event.Permission, event.Reason)
+	if err := s.db.Create(event).Error; err != nil {
+		log.Printf("Failed to store audit event: %v", err)
+	}
+}
+
+// List returns recent events, newest first, optionally of one type or for
+// one user.
+func (s *AuditService) List(eventType, userID string, limit int) ([]models.AuditEvent, error) {
+	if limit <= 0 {
+		limit = defaultAuditPageSize
+	}
+	if limit > maxAuditPageSize {
+		limit = maxAuditPageSize
+	}
+
+	query := s.db.Order("created_at DESC").Limit(limit)
+	if eventType != "" {
+		query = query.Where("type = ?", eventType)
+	}
+	if userID != "" {
+		query = query.Where("user_id = ?", userID)
+	}
+
+	var events []models.AuditEvent
+	err := query.Find(&events).Error
+	return events, err
+}
@@ -0,0 +1,396 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// Token types, stored in the typ claim so a refresh token can't be used as
+// an access token or the other way round.
+const (
+	AccessToken  = "access"
+	RefreshToken = "refresh"
+)
+
+const tokenDenylistPrefix = "auth:denylist:"
+
+var (
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrInvalidToken       = errors.New("invalid token")
+	ErrTokenRevoked       = errors.New("token has been revoked")
+	ErrInvalidSigningKeys = errors.New("invalid JWT signing keys")
+)
+
+// Claims are the claims of access and refresh tokens. A token is issued for
+// one organization: Role is the user's role in it and every request made
+// with the token only sees its data. PlatformRole is the user's own role,
+// which grants the platform permissions. SessionID names the login the
+// token belongs to.
+type Claims struct {
+	UserID         string `json:"user_id"`
+	Email          string `json:"email"`
+	OrganizationID string `json:"org_id,omitempty"`
+	Role           string `json:"role"`
+	PlatformRole   string `json:"platform_role"`
+	SessionID      string `json:"sid,omitempty"`
+	Type           string `json:"typ"`
+	jwt.RegisteredClaims
+}
+
+// TokenPair is returned by login and refresh.
+type TokenPair struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"` // Access token lifetime in seconds
+}
+
+// SigningKeys are the HMAC secrets tokens are signed with, by key ID. New
+// tokens are signed with Current; tokens signed with any listed key are
+// accepted, so a key can be rotated out once its tokens have expired.
+type SigningKeys struct {
+	Current string
+	Secrets map[string][]byte
+}
+
+// ParseSigningKeys builds the key set from the current key and a comma
+// separated list of previous keys written as id:secret.
+func ParseSigningKeys(currentID, currentSecret, previous string) (SigningKeys, error) {
+	if currentID == "" {
+		return SigningKeys{}, fmt.Errorf("%w: the current key needs an ID", ErrInvalidSigningKeys)
+	}
+	if currentSecret == "" {
+		// A random secret would log everyone out on every restart and
+		// differ between the API and worker processes.
+		return SigningKeys{}, fmt.Errorf("%w: JWT_SECRET is not set", ErrInvalidSigningKeys)
+	}
+
+	keys := SigningKeys{Current: currentID, Secrets: map[string][]byte{currentID: []byte(currentSecret)}}
+	for _, entry := range strings.Split(previous, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, secret, ok := strings.Cut(entry, ":")
+		if !ok || id == "" || secret == "" {
+			return SigningKeys{}, fmt.Errorf("%w: previous keys must be written as id:secret", ErrInvalidSigningKeys)
+		}
+		if _, exists := keys.Secrets[id]; exists {
+			return SigningKeys{}, fmt.Errorf("%w: duplicate key ID %q", ErrInvalidSigningKeys, id)
+		}
+		keys.Secrets[id] = []byte(secret)
+	}
+	return keys, nil
+}
+
+// AuthService issues and verifies JWTs. Every login starts a refresh
+// session, so a user can be logged in on several devices. Refreshing rotates
+// the session's refresh token, and presenting a replaced one ends that
+// session only. Logged out access tokens are kept in a Redis denylist until
+// they expire.
+type AuthService struct {
+	db            *gorm.DB
+	redis         *redis.Client
+	organizations *OrganizationService
+	keys          SigningKeys
+	accessTTL     time.Duration
+	refreshTTL    time.Duration
+}
+
+func NewAuthService(db *gorm.DB, redisClient *redis.Client, organizations *OrganizationService, keys SigningKeys, accessTTL, refreshTTL time.Duration) *AuthService {
+	return &AuthService{
+		db:            db,
+		redis:         redisClient,
+		organizations: organizations,
+		keys:          keys,
+		accessTTL:     accessTTL,
+		refreshTTL:    refreshTTL,
+	}
+}
+
+// Login checks the password and starts a new session. The user's other
+// sessions are kept. The session is for the given organization, or the
+// user's oldest membership when organizationID is empty.
+func (s *AuthService) Login(email, password, organizationID string) (*models.User, *TokenPair, error) {
+	var user models.User
+	err := s.db.Where("email = ?", strings.TrimSpace(email)).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if !models.CheckPassword(user.Password, password) {
+		return nil, nil, ErrInvalidCredentials
+	}
+
+	member, err := s.membership(user.ID, organizationID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pair, err := s.startSession(&user, member)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &user, pair, nil
+}
+
+// Refresh exchanges a refresh token for a new token pair. The old refresh
+// token stops working. If it had already been replaced, the token was
+// probably stolen, so its session is ended; the user's other sessions are
+// not affected.
+func (s *AuthService) Refresh(ctx context.Context, refreshToken string) (*TokenPair, error) {
+	claims, err := s.Parse(ctx, refreshToken, RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+	// Tokens issued before sessions existed cannot be refreshed.
+	if claims.SessionID == "" {
+		return nil, ErrInvalidToken
+	}
+
+	var session models.RefreshSession
+	err = s.db.Where("id = ? AND user_id = ?", claims.SessionID, claims.UserID).First(&session).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(claims.ID), []byte(session.TokenID)) != 1 {
+		log.Printf("Refresh token reuse in session %s of user %s, ending the session", session.ID, session.UserID)
+		if err := s.db.Delete(&session).Error; err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidToken
+	}
+
+	var user models.User
+	if err := s.db.Where("id = ?", claims.UserID).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	// The role is looked up again so role changes apply on refresh.
+	member, err := s.membership(user.ID, claims.OrganizationID)
+	if errors.Is(err, ErrNotOrganizationMember) {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	pair, refresh, err := s.issue(&user, member, session.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only one of two concurrent refreshes with the same token wins.
+	result := s.db.Model(&models.RefreshSession{}).
+		Where("id = ? AND token_id = ?", session.ID, claims.ID).
+		Updates(map[string]interface{}{"token_id": refresh.ID, "expires_at": refresh.ExpiresAt.Time})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrInvalidToken
+	}
+	return pair, nil
+}
+
+// SwitchOrganization starts a session for another organization the user
+// belongs to. The current access token is revoked and its session replaced.
+func (s *AuthService) SwitchOrganization(ctx context.Context, claims *Claims, organizationID string) (*TokenPair, error) {
+	var user models.User
+	if err := s.db.Where("id = ?", claims.UserID).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	member, err := s.organizations.Membership(organizationID, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.endSession(claims); err != nil {
+		return nil, err
+	}
+	pair, err := s.startSession(&user, member)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Revoke(ctx, claims); err != nil {
+		return nil, err
+	}
+	return pair, nil
+}
+
+// membership returns the user's membership of the organization, or of their
+// oldest organization when organizationID is empty. Users who belong to no
+// organization get a nil membership.
+func (s *AuthService) membership(userID, organizationID string) (*models.OrganizationMember, error) {
+	if organizationID == "" {
+		return s.organizations.DefaultMembership(userID)
+	}
+	return s.organizations.Membership(organizationID, userID)
+}
+
+// Logout revokes the access token and ends its session. The user's other
+// sessions are kept.
+func (s *AuthService) Logout(ctx context.Context, claims *Claims) error {
+	if err := s.Revoke(ctx, claims); err != nil {
+		return err
+	}
+	return s.endSession(claims)
+}
+
+// startSession stores a new refresh session for the user and issues its
+// first token pair. Expired sessions of the user are removed on the way.
+func (s *AuthService) startSession(user *models.User, member *models.OrganizationMember) (*TokenPair, error) {
+	session := models.RefreshSession{ID: uuid.New().String(), UserID: user.ID}
+	pair, refresh, err := s.issue(user, member, session.ID)
+	if err != nil {
+		return nil, err
+	}
+	session.TokenID = refresh.ID
+	session.ExpiresAt = refresh.ExpiresAt.Time
+
+	if err := s.db.Where("user_id = ? AND expires_at < ?", user.ID, time.Now()).Delete(&models.RefreshSession{}).Error; err != nil {
+		return nil, err
+	}
+	if err := s.db.Create(&session).Error; err != nil {
+		return nil, err
+	}
+	return pair, nil
+}
+
+// endSession ends the session the token belongs to.
+func (s *AuthService) endSession(claims *Claims) error {
+	if claims.SessionID == "" {
+		return nil
+	}
+	return s.db.Where("id = ? AND user_id = ?", claims.SessionID, claims.UserID).Delete(&models.RefreshSession{}).Error
+}
+
+// EndSessions ends every session of the user, so their refresh tokens stop
+// working and the next login picks up role changes. Access tokens already
+// issued stay valid until they expire.
+func EndSessions(db *gorm.DB, userID string) error {
+	return db.Where("user_id = ?", userID).Delete(&models.RefreshSession{}).Error
+}
+
+// Revoke adds the token's ID to the denylist until the token expires.
+func (s *AuthService) Revoke(ctx context.Context, claims *Claims) error {
+	if claims.ID == "" || claims.ExpiresAt == nil {
+		return nil
+	}
+	ttl := time.Until(claims.ExpiresAt.Time)
+	if ttl <= 0 {
+		return nil
+	}
+	return s.redis.Set(ctx, tokenDenylistPrefix+claims.ID, 1, ttl).Err()
+}
+
+// Parse verifies a token's signature, expiry and type and checks that it
+// has not been revoked.
+func (s *AuthService) Parse(ctx context.Context, tokenString, tokenType string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, s.keyFunc)
+	if err != nil || !token.Valid || claims.Type != tokenType || claims.ID == "" {
+		return nil, ErrInvalidToken
+	}
+
+	revoked, err := s.redis.Exists(ctx, tokenDenylistPrefix+claims.ID).Result()
+	if err != nil {
+		return nil, err
+	}
+	if revoked > 0 {
+		return nil, ErrTokenRevoked
+	}
+	return claims, nil
+}
+
+func (s *AuthService) keyFunc(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
+	}
+	kid, _ := token.Header["kid"].(string)
+	secret, ok := s.keys.Secrets[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key ID %q", kid)
+	}
+	return secret, nil
+}
+
+// issue signs a new access and refresh token of the session for the user's
+// membership and returns the claims of the refresh token to store.
+func (s *AuthService) issue(user *models.User, member *models.OrganizationMember, sessionID string) (*TokenPair, *Claims, error) {
+	accessToken, _, err := s.sign(user, member, sessionID, AccessToken, s.accessTTL)
+	if err != nil {
+		return nil, nil, err
+	}
+	refreshToken, refresh, err := s.sign(user, member, sessionID, RefreshToken, s.refreshTTL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(s.accessTTL.Seconds()),
+	}, refresh, nil
+}
+
+func (s *AuthService) sign(user *models.User, member *models.OrganizationMember, sessionID, tokenType string, ttl time.Duration) (string, *Claims, error) {
+	now := time.Now()
+	claims := &Claims{
+		UserID:       user.ID,
+		Email:        user.Email,
+		PlatformRole: user.Role,
+		SessionID:    sessionID,
+		Type:         tokenType,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			Subject:   user.ID,
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}
+
+	if member != nil {
+		claims.OrganizationID = member.OrganizationID
+		claims.Role = member.Role
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token.Header["kid"] = s.keys.Current
+	signed, err := token.SignedString(s.keys.Secrets[s.keys.Current])
+	if err != nil {
+		return "", nil, err
+	}
+	return signed, claims, nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestParseSigningKeys(t *testing.T) {
+	keys, err := ParseSigningKeys("k2", "new-secret", "k1:old-secret")
+	if err != nil {
+		t.Fatalf("ParseSigningKeys: %v", err)
+	}
+	if keys.Current != "k2" || string(keys.Secrets["k2"]) != "new-secret" || string(keys.Secrets["k1"]) != "old-secret" {
+		t.Errorf("got %+v", keys)
+	}
+
+	for name, args := range map[string][3]string{
+		"empty secret":         {"k1", "", ""},
+		"missing key ID":       {"", "secret", ""},
+		"malformed previous":   {"k2", "secret", "old-secret"},
+		"duplicate previous":   {"k2", "secret", "k2:old-secret"},
+		"previous without key": {"k2", "secret", ":old-secret"},
+	} {
+		if _, err := ParseSigningKeys(args[0], args[1], args[2]); !errors.Is(err, ErrInvalidSigningKeys) {
+			t.Errorf("%s: err = %v, want ErrInvalidSigningKeys", name, err)
+		}
+	}
+}
+
+func newTestAuthService(t *testing.T) *AuthService {
+	t.Helper()
+	keys, err := ParseSigningKeys("k1", "secret", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewAuthService(nil, client, nil, keys, time.Minute, time.Hour)
+}
+
+func TestIssuedTokensCarryTheSession(t *testing.T) {
+	auth := newTestAuthService(t)
+	user := &models.User{ID: "user-1", Email: "jane@example.com", Role: models.RolePending}
+	member := &models.OrganizationMember{OrganizationID: "org-1", Role: models.RoleRecruiter}
+
+	pair, refresh, err := auth.issue(user, member, "session-1")
+	if err != nil {
+		t.Fatalf("issue: %v", err)
+	}
+
+	access, err := auth.Parse(context.Background(), pair.AccessToken, AccessToken)
+	if err != nil {
+		t.Fatalf("Parse access token: %v", err)
+	}
+	parsed, err := auth.Parse(context.Background(), pair.RefreshToken, RefreshToken)
+	if err != nil {
+		t.Fatalf("Parse refresh token: %v", err)
+	}
+	if access.SessionID != "session-1" || parsed.SessionID != "session-1" {
+		t.Errorf("session IDs %q and %q, want session-1", access.SessionID, parsed.SessionID)
+	}
+	if parsed.ID != refresh.ID || access.ID == refresh.ID {
+		t.Errorf("refresh claims %q do not identify the refresh token %q", refresh.ID, parsed.ID)
+	}
+	if access.Role != models.RoleRecruiter || access.PlatformRole != models.RolePending || access.OrganizationID != "org-1" {
+		t.Errorf("access claims %+v", access)
+	}
+}
+
+func TestRefreshRejectsTokensWithoutSession(t *testing.T) {
+	auth := newTestAuthService(t)
+	user := &models.User{ID: "user-1", Email: "jane@example.com"}
+
+	token, _, err := auth.sign(user, nil, "", RefreshToken, time.Hour)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	if _, err := auth.Refresh(context.Background(), token); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Refresh = %v, want ErrInvalidToken", err)
+	}
+}
@@ -0,0 +1,273 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	defaultMatchConcurrency = 4
+	defaultMatchBatchSize   = 100
+)
+
+// BulkMatchResult summarizes a bulk matching run.
+type BulkMatchResult struct {
+	JobID        string                `json:"job_id"`
+	Mode         string                `json:"mode"`
+	Processed    int                   `json:"processed"`
+	Saved        int                   `json:"saved"`
+	Disqualified int                   `json:"disqualified"`
+	AIFallbacks  int                   `json:"ai_fallbacks"` // Scores that fell back to heuristic because the AI call failed
+	Failures     []models.MatchFailure `json:"failures"`
+}
+
+// BulkMatchService scores every resume against a job with a bounded pool
+// of workers. Resumes are streamed from the database in batches and scores
+// are written with batched inserts, so memory stays flat however many
+// resumes there are. AI calls are paced by the AI service's rate limit.
+type BulkMatchService struct {
+	db          *gorm.DB
+	matcher     *JobMatcherService
+	concurrency int
+	batchSize   int
+	progress    func(*BulkMatchResult) error
+}
+
+func NewBulkMatchService(db *gorm.DB, matcher *JobMatcherService, concurrency, batchSize int) *BulkMatchService {
+	if concurrency <= 0 {
+		concurrency = defaultMatchConcurrency
+	}
+	if batchSize <= 0 {
+		batchSize = defaultMatchBatchSize
+	}
+	return &BulkMatchService{
+		db:          db,
+		matcher:     matcher,
+		concurrency: concurrency,
+		batchSize:   batchSize,
+	}
+}
+
+// OnProgress registers fn to be called after every batch of scores is
+// written. Returning an error from fn stops the run; scores already computed
+// are still saved.
+func (s *BulkMatchService) OnProgress(fn func(*BulkMatchResult) error) {
+	s.progress = fn
+}
+
+type scoredResume struct {
+	resumeID string
+	score    *models.CandidateScore
+	err      error
+}
+
+// pendingResumes selects the resumes a run has to score. A full run takes
+// every resume. Otherwise only resumes without a current score, or whose
+// score is out of date, are picked: the resume or job changed since it was
+// scored, it was computed by an older algorithm or another profile version,
+// or in another mode (AI fallbacks are retried, disqualified scores are not).
+func (s *BulkMatchService) pendingResumes(ctx context.Context, job *models.JobDescription, mode string, full bool) *gorm.DB {
+	query := s.db.WithContext(ctx).Model(&models.Resume{})
+	if full {
+		return query
+	}
+
+	profile := scoringProfileFor(job)
+	var profileID *string
+	if profile.ID != "" {
+		profileID = &profile.ID
+	}
+
+	return query.
+		Joins("LEFT JOIN candidate_scores cs ON cs.resume_id = resumes.id AND cs.job_id = ?", job.ID).
+		Where(`cs.id IS NULL OR cs.scored_at IS NULL
+			OR cs.stale OR cs.scored_at < resumes.updated_at
+			OR cs.algorithm_version IS DISTINCT FROM ?
+			OR cs.scoring_profile_id IS DISTINCT FROM ? OR cs.scoring_profile_version <> ?
+			OR (cs.scoring_mode <> ? AND NOT cs.disqualified)`,
+			ScoringAlgorithmVersion, profileID, profile.Version, mode)
+}
+
+// Count returns how many resumes a run with the same arguments would score.
+func (s *BulkMatchService) Count(ctx context.Context, job *models.JobDescription, mode string, full bool) (int64, error) {
+	var total int64
+	err := s.pendingResumes(ctx, job, mode, full).Count(&total).Error
+	return total, err
+}
+
+// Run scores the job's pending resumes, or all of them when full is set. It returns an error only when the
+// run as a whole fails; individual resumes that fail are listed in the
+// result and the run carries on.
+func (s *BulkMatchService) Run(ctx context.Context, job *models.JobDescription, mode string, full bool) (*BulkMatchResult, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	resumes := make(chan models.Resume, s.batchSize)
+	results := make(chan scoredResume, s.batchSize)
+
+	// Producer: stream resumes in batches
+	var loadErr error
+	go func() {
+		defer close(resumes)
+
+		var batch []models.Resume
+		loadErr = s.pendingResumes(ctx, job, mode, full).Select("resumes.*").
+			Preload("Education").Preload("Experience").
+			FindInBatches(&batch, s.batchSize, func(tx *gorm.DB, _ int) error {
+				for _, resume := range batch {
+					select {
+					case resumes <- resume:
+					case <-ctx.Done():
+						return ctx.Err()
+					}
+				}
+				return nil
+			}).Error
+	}()
+
+	// Workers: score resumes
+	var workers sync.WaitGroup
+	for i := 0; i < s.concurrency; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for resume := range resumes {
+				results <- s.score(ctx, mode, &resume, job)
+			}
+		}()
+	}
+	go func() {
+		workers.Wait()
+		close(results)
+	}()
+
+	// Writer: save scores in batches on this goroutine
+	result := &BulkMatchResult{JobID: job.ID, Mode: mode, Failures: []models.MatchFailure{}}
+	pending := make([]models.CandidateScore, 0, s.batchSize)
+	var stopErr error
+	for scored := range results {
+		if scored.err != nil && ctx.Err() != nil {
+			// Skipped because the run was stopped, not a failure
+			continue
+		}
+		result.Processed++
+		if scored.err != nil {
+			result.Failures = append(result.Failures, models.MatchFailure{ResumeID: scored.resumeID, Error: scored.err.Error()})
+			continue
+		}
+		if scored.score.Disqualified {
+			result.Disqualified++
+		} else if scored.score.ScoringMode != mode {
+			result.AIFallbacks++
+		}
+
+		pending = append(pending, *scored.score)
+		if len(pending) == s.batchSize {
+			s.flush(ctx, pending, result)
+			pending = pending[:0]
+			if err := s.report(result); err != nil && stopErr == nil {
+				stopErr = err
+				cancel()
+			}
+		}
+	}
+	s.flush(ctx, pending, result)
+	if err := s.report(result); err != nil && stopErr == nil {
+		stopErr = err
+	}
+
+	if stopErr != nil {
+		return result, stopErr
+	}
+	if loadErr != nil && ctx.Err() == nil {
+		return result, fmt.Errorf("loading resumes: %w", loadErr)
+	}
+	return result, ctx.Err()
+}
+
+func (s *BulkMatchService) report(result *BulkMatchResult) error {
+	if s.progress == nil {
+		return nil
+	}
+	return s.progress(result)
+}
+
+// score runs the matcher for one resume. A panic while scoring one resume is
+// reported as that resume's failure instead of taking down the run.
+func (s *BulkMatchService) score(ctx context.Context, mode string, resume *models.Resume, job *models.JobDescription) (scored scoredResume) {
+	scored.resumeID = resume.ID
+	defer func() {
+		if r := recover(); r != nil {
+			scored.err = fmt.Errorf("scoring failed: %v", r)
+		}
+	}()
+
+	if err := ctx.Err(); err != nil {
+		scored.err = err
+		return scored
+	}
+	scored.score = s.matcher.Match(ctx, mode, resume, job)
+	return scored
+}
+
+// flush upserts a batch of scores. If the batch fails, the scores are
+// written one by one so only the rows that really fail are reported.
+func (s *BulkMatchService) flush(ctx context.Context, scores []models.CandidateScore, result *BulkMatchResult) {
+	if len(scores) == 0 {
+		return
+	}
+
+	// Scores computed before a cancellation are still worth keeping.
+	db := s.db.WithContext(context.WithoutCancel(ctx))
+	if err := saveScores(db, scores, s.batchSize); err == nil {
+		result.Saved += len(scores)
+		return
+	}
+
+	for i := range scores {
+		if err := saveScores(db, scores[i:i+1], 1); err != nil {
+			result.Failures = append(result.Failures, models.MatchFailure{ResumeID: scores[i].ResumeID, Error: err.Error()})
+			continue
+		}
+		result.Saved++
+	}
+}
+
+// saveScores replaces the current scores of the given resumes for their job,
+// moving the previous scores into candidate_score_history first.
+func saveScores(db *gorm.DB, scores []models.CandidateScore, batchSize int) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		byJob := make(map[string][]string)
+		for _, score := range scores {
+			byJob[score.JobID] = append(byJob[score.JobID], score.ResumeID)
+		}
+		for jobID, resumeIDs := range byJob {
+			if err := archiveScores(tx, jobID, resumeIDs); err != nil {
+				return err
+			}
+		}
+
+		return tx.Omit(clause.Associations).
+			Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "job_id"}, {Name: "resume_id"}},
+				UpdateAll: true,
+			}).
+			CreateInBatches(scores, batchSize).Error
+	})
+}
+
+func archiveScores(tx *gorm.DB, jobID string, resumeIDs []string) error {
+	return tx.Exec(`INSERT INTO candidate_score_history
+		(candidate_score_id, job_id, resume_id, score, required_match, nice_to_have_match,
+		 experience_match, education_match, ai_enhanced, ai_score, scoring_mode, disqualified,
+		 scoring_profile_id, scoring_profile_version, algorithm_version, ai_model, scored_at, archived_at)
+		SELECT id, job_id, resume_id, score, required_match, nice_to_have_match,
+		 experience_match, education_match, ai_enhanced, ai_score, scoring_mode, disqualified,
+		 scoring_profile_id, scoring_profile_version, algorithm_version, ai_model, scored_at, NOW()
+		FROM candidate_scores WHERE job_id = ? AND resume_id IN ?`, jobID, resumeIDs).Error
+}
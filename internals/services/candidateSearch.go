@@ -0,0 +1,244 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/taxonomy"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultCandidatePageSize = 20
+	maxCandidatePageSize     = 100
+)
+
+var ErrInvalidCandidateFilter = errors.New("invalid candidate filter")
+
+// candidateSortColumns are the columns candidates can be sorted by.
+var candidateSortColumns = map[string]string{
+	"score":              "candidate_scores.score",
+	"required_match":     "candidate_scores.required_match",
+	"nice_to_have_match": "candidate_scores.nice_to_have_match",
+	"experience_match":   "candidate_scores.experience_match",
+	"education_match":    "candidate_scores.education_match",
+	"ai_score":           "candidate_scores.ai_score",
+	"experience_months":  "candidate_scores.experience_months",
+	"created_at":         "candidate_scores.created_at",
+}
+
+// resumeSummaryColumns are loaded with each candidate. The parsed text is
+// left out unless asked for, since it is by far the largest column.
+var resumeSummaryColumns = []string{"id", "candidate_name", "email", "phone", "location", "skills", "certifications", "file_path", "created_at", "updated_at"}
+
+// CandidateFilter narrows and orders the candidates of a job.
+type CandidateFilter struct {
+	MinScore            *int       `form:"min_score"`
+	MinRequiredMatch    *float64   `form:"min_required_match"` // 0-1 share of required skills
+	Skills              []string   `form:"skills"`             // Skills the resume must list
+	SkillsMode          string     `form:"skills_mode"`        // all (default) or any
+	MinYears            *float64   `form:"min_years"`
+	EducationLevel      string     `form:"education_level"` // Minimum level: high_school, associate, bachelor, master or phd
+	AIEnhancedOnly      bool       `form:"ai_enhanced"`
+	CreatedAfter        *time.Time `form:"-"` // Candidates whose resume was added after this time
+	IncludeDisqualified bool       `form:"include_disqualified"`
+	IncludeParsedText   bool       `form:"include_parsed_text"`
+	Sort                string     `form:"sort"`  // One of candidateSortColumns, default score
+	Order               string     `form:"order"` // desc (default) or asc
+	Cursor              string     `form:"cursor"`
+	Limit               int        `form:"limit"`
+}
+
+// CandidatePage is one page of candidates. NextCursor is empty on the last
+// page.
+type CandidatePage struct {
+	Candidates []models.CandidateScore `json:"candidates"`
+	NextCursor string                  `json:"next_cursor,omitempty"`
+}
+
+// candidateCursor marks the last row of a page: the sort it belongs to, the
+// row's sort value and its ID.
+type candidateCursor struct {
+	Sort  string      `json:"s"`
+	Order string      `json:"o"`
+	Value interface{} `json:"v"`
+	ID    string      `json:"id"`
+}
+
+// SearchCandidates returns a page of the job's candidates matching the
+// filter, using keyset pagination on the sort column and score ID.
+func SearchCandidates(db *gorm.DB, jobID string, filter CandidateFilter) (*CandidatePage, error) {
+	sortKey := filter.Sort
+	if sortKey == "" {
+		sortKey = "score"
+	}
+	column, ok := candidateSortColumns[sortKey]
+	if !ok {
+		return nil, fmt.Errorf("%w: cannot sort by %q", ErrInvalidCandidateFilter, sortKey)
+	}
+
+	order := strings.ToLower(filter.Order)
+	switch order {
+	case "":
+		order = "desc"
+	case "asc", "desc":
+	default:
+		return nil, fmt.Errorf("%w: order must be asc or desc", ErrInvalidCandidateFilter)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultCandidatePageSize
+	}
+	if limit > maxCandidatePageSize {
+		limit = maxCandidatePageSize
+	}
+
+	query := db.Model(&models.CandidateScore{}).
+		Joins("JOIN resumes ON resumes.id = candidate_scores.resume_id").
+		Where("candidate_scores.job_id = ?", jobID)
+
+	if !filter.IncludeDisqualified {
+		query = query.Where("candidate_scores.disqualified = ?", false)
+	}
+	if filter.MinScore != nil {
+		query = query.Where("candidate_scores.score >= ?", *filter.MinScore)
+	}
+	if filter.MinRequiredMatch != nil {
+		query = query.Where("candidate_scores.required_match >= ?", *filter.MinRequiredMatch)
+	}
+	if filter.MinYears != nil {
+		query = query.Where("candidate_scores.experience_months >= ?", int(*filter.MinYears*12))
+	}
+	if filter.AIEnhancedOnly {
+		query = query.Where("candidate_scores.ai_enhanced = ?", true)
+	}
+	if filter.CreatedAfter != nil {
+		query = query.Where("resumes.created_at > ?", *filter.CreatedAfter)
+	}
+
+	if filter.EducationLevel != "" {
+		levels, err := educationLevelsAtLeast(filter.EducationLevel)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("candidate_scores.education_level IN ?", levels)
+	}
+
+	if skills := taxonomy.Default().Normalize(filter.Skills); len(skills) > 0 {
+		switch strings.ToLower(filter.SkillsMode) {
+		case "", "all":
+			query = query.Where("resumes.skills @> ARRAY[?]::text[]", skills)
+		case "any":
+			query = query.Where("resumes.skills && ARRAY[?]::text[]", skills)
+		default:
+			return nil, fmt.Errorf("%w: skills_mode must be all or any", ErrInvalidCandidateFilter)
+		}
+	}
+
+	comparison := "<"
+	if order == "asc" {
+		comparison = ">"
+	}
+	if filter.Cursor != "" {
+		cursor, err := decodeCandidateCursor(filter.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if cursor.Sort != sortKey || cursor.Order != order {
+			return nil, fmt.Errorf("%w: cursor belongs to a different sort order", ErrInvalidCandidateFilter)
+		}
+		query = query.Where(fmt.Sprintf("(%s, candidate_scores.id) %s (?, ?)", column, comparison), cursor.Value, cursor.ID)
+	}
+
+	resumeColumns := append([]string(nil), resumeSummaryColumns...)
+	if filter.IncludeParsedText {
+		resumeColumns = append(resumeColumns, "parsed_text")
+	}
+
+	var scores []models.CandidateScore
+	err := query.
+		Select("candidate_scores.*").
+		Preload("Resume", func(db *gorm.DB) *gorm.DB { return db.Select(resumeColumns) }).
+		Order(fmt.Sprintf("%s %s, candidate_scores.id %s", column, order, order)).
+		Limit(limit + 1).
+		Find(&scores).Error
+	if err != nil {
+		return nil, err
+	}
+
+	page := &CandidatePage{Candidates: scores}
+	if len(scores) > limit {
+		page.Candidates = scores[:limit]
+		page.NextCursor = encodeCandidateCursor(sortKey, order, &scores[limit-1])
+	}
+	return page, nil
+}
+
+// ParseCandidateTime accepts an RFC 3339 timestamp or a plain date.
+func ParseCandidateTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %q is not a date", ErrInvalidCandidateFilter, value)
+	}
+	return t, nil
+}
+
+func educationLevelsAtLeast(name string) ([]string, error) {
+	want := ParseRequiredEducationLevel(name)
+	if want == EducationUnknown {
+		return nil, fmt.Errorf("%w: unknown education level %q", ErrInvalidCandidateFilter, name)
+	}
+
+	var levels []string
+	for level := want; level <= EducationPhD; level++ {
+		levels = append(levels, level.String())
+	}
+	return levels, nil
+}
+
+func encodeCandidateCursor(sortKey, order string, score *models.CandidateScore) string {
+	var value interface{}
+	switch sortKey {
+	case "score":
+		value = score.Score
+	case "required_match":
+		value = score.RequiredMatch
+	case "nice_to_have_match":
+		value = score.NiceToHaveMatch
+	case "experience_match":
+		value = score.ExperienceMatch
+	case "education_match":
+		value = score.EducationMatch
+	case "ai_score":
+		value = score.AIScore
+	case "experience_months":
+		value = score.ExperienceMonths
+	case "created_at":
+		value = score.CreatedAt.Format(time.RFC3339Nano)
+	}
+
+	data, _ := json.Marshal(candidateCursor{Sort: sortKey, Order: order, Value: value, ID: score.ID})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCandidateCursor(encoded string) (*candidateCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidCandidateFilter)
+	}
+
+	var cursor candidateCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == "" || cursor.Value == nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidCandidateFilter)
+	}
+	return &cursor, nil
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+)
+
+var ErrCircuitOpen = errors.New("AI provider circuit breaker is open")
+
+const (
+	BreakerClosed   = "closed"
+	BreakerOpen     = "open"
+	BreakerHalfOpen = "half_open"
+)
+
+// BreakerState is a snapshot of a circuit breaker for operators.
+type BreakerState struct {
+	Provider            string     `json:"provider"`
+	State               string     `json:"state"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	OpenedAt            *time.Time `json:"opened_at,omitempty"`
+	LastError           string     `json:"last_error,omitempty"`
+}
+
+// circuitBreaker stops calling a provider after threshold consecutive
+// failures. Once cooldown has passed a single probe call is let through:
+// success closes the breaker, failure opens it for another cooldown.
+type circuitBreaker struct {
+	mu            sync.Mutex
+	provider      string
+	threshold     int
+	cooldown      time.Duration
+	state         string
+	failures      int
+	openedAt      time.Time
+	lastError     string
+	probeInFlight bool
+	now           func() time.Time // Replaced in tests
+}
+
+func newCircuitBreaker(provider string, threshold int, cooldown time.Duration) *circuitBreaker {
+	if threshold <= 0 {
+		threshold = 5
+	}
+	if cooldown <= 0 {
+		cooldown = 30 * time.Second
+	}
+	return &circuitBreaker{
+		provider:  provider,
+		threshold: threshold,
+		cooldown:  cooldown,
+		state:     BreakerClosed,
+		now:       time.Now,
+	}
+}
+
+// Allow returns ErrCircuitOpen if the call should not be attempted.
+func (b *circuitBreaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return ErrCircuitOpen
+		}
+		b.setState(BreakerHalfOpen)
+		b.probeInFlight = true
+		return nil
+	case BreakerHalfOpen:
+		if b.probeInFlight {
+			return ErrCircuitOpen
+		}
+		b.probeInFlight = true
+		return nil
+	}
+	return nil
+}
+
+func (b *circuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.probeInFlight = false
+	if b.state != BreakerClosed {
+		b.setState(BreakerClosed)
+	}
+}
+
+func (b *circuitBreaker) Failure(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.probeInFlight = false
+	if err != nil {
+		b.lastError = err.Error()
+	}
+
+	if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+		b.openedAt = b.now()
+		b.setState(BreakerOpen)
+	}
+}
+
+// Abandon releases a half-open probe whose caller gave up, without judging
+// the provider either way.
+func (b *circuitBreaker) Abandon() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probeInFlight = false
+}
+
+func (b *circuitBreaker) Snapshot() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state := BreakerState{
+		Provider:            b.provider,
+		State:               b.state,
+		ConsecutiveFailures: b.failures,
+		LastError:           b.lastError,
+	}
+	if b.state != BreakerClosed {
+		openedAt := b.openedAt
+		state.OpenedAt = &openedAt
+	}
+	return state
+}
+
+// setState must be called with b.mu held.
+func (b *circuitBreaker) setState(state string) {
+	if b.state != state {
+		log.Printf("AI circuit breaker for %s: %s -> %s", b.provider, b.state, state)
+	}
+	b.state = state
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// testClock is a manually advanced clock for circuit breaker tests.
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time { return c.now }
+
+func (c *testClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newTestBreaker(threshold int, cooldown time.Duration) (*circuitBreaker, *testClock) {
+	clock := &testClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	breaker := newCircuitBreaker("test", threshold, cooldown)
+	breaker.now = clock.Now
+	return breaker, clock
+}
+
+func TestCircuitBreakerTransitions(t *testing.T) {
+	breaker, clock := newTestBreaker(3, time.Minute)
+	failure := errors.New("boom")
+
+	// Failures below the threshold, and a success resetting the count,
+	// keep the breaker closed.
+	breaker.Failure(failure)
+	breaker.Failure(failure)
+	breaker.Success()
+	breaker.Failure(failure)
+	breaker.Failure(failure)
+	if state := breaker.Snapshot(); state.State != BreakerClosed || state.ConsecutiveFailures != 2 {
+		t.Fatalf("after 2 failures: %+v", state)
+	}
+	if err := breaker.Allow(); err != nil {
+		t.Fatalf("closed breaker refused a call: %v", err)
+	}
+
+	breaker.Failure(failure)
+	state := breaker.Snapshot()
+	if state.State != BreakerOpen || state.LastError != "boom" || state.OpenedAt == nil || !state.OpenedAt.Equal(clock.now) {
+		t.Fatalf("after reaching the threshold: %+v", state)
+	}
+	if err := breaker.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("open breaker allowed a call: %v", err)
+	}
+
+	// After the cooldown a single probe is let through.
+	clock.Advance(time.Minute - time.Second)
+	if err := breaker.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("breaker allowed a call before the cooldown: %v", err)
+	}
+	clock.Advance(time.Second)
+	if err := breaker.Allow(); err != nil {
+		t.Fatalf("breaker refused the probe: %v", err)
+	}
+	if state := breaker.Snapshot().State; state != BreakerHalfOpen {
+		t.Fatalf("state during the probe = %s", state)
+	}
+	if err := breaker.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("breaker allowed a second probe: %v", err)
+	}
+
+	// A failed probe opens the breaker for another cooldown.
+	breaker.Failure(failure)
+	if state := breaker.Snapshot(); state.State != BreakerOpen || !state.OpenedAt.Equal(clock.now) {
+		t.Fatalf("after a failed probe: %+v", state)
+	}
+	if err := breaker.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("breaker allowed a call right after a failed probe: %v", err)
+	}
+
+	// A successful probe closes it.
+	clock.Advance(time.Minute)
+	if err := breaker.Allow(); err != nil {
+		t.Fatalf("breaker refused the second probe: %v", err)
+	}
+	breaker.Success()
+	if state := breaker.Snapshot(); state.State != BreakerClosed || state.ConsecutiveFailures != 0 || state.OpenedAt != nil {
+		t.Fatalf("after a successful probe: %+v", state)
+	}
+}
+
+func TestCircuitBreakerAbandon(t *testing.T) {
+	breaker, clock := newTestBreaker(1, time.Minute)
+	breaker.Failure(errors.New("boom"))
+	clock.Advance(time.Minute)
+
+	if err := breaker.Allow(); err != nil {
+		t.Fatalf("breaker refused the probe: %v", err)
+	}
+	breaker.Abandon()
+
+	if state := breaker.Snapshot().State; state != BreakerHalfOpen {
+		t.Fatalf("Abandon changed the state to %s", state)
+	}
+	if err := breaker.Allow(); err != nil {
+		t.Fatalf("breaker refused a new probe after Abandon: %v", err)
+	}
+	if err := breaker.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("breaker allowed two probes: %v", err)
+	}
+}
+
+func TestNewCircuitBreakerDefaults(t *testing.T) {
+	breaker := newCircuitBreaker("test", 0, 0)
+	if breaker.threshold != 5 || breaker.cooldown != 30*time.Second {
+		t.Errorf("threshold %d cooldown %v", breaker.threshold, breaker.cooldown)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	for attempt := 1; attempt <= 70; attempt++ {
+		want := aiRetryBaseDelay << (attempt - 1)
+		if attempt > 5 {
+			want = aiRetryMaxDelay
+		}
+		for i := 0; i < 20; i++ {
+			if got := retryDelay(attempt); got < want/2 || got > want {
+				t.Fatalf("retryDelay(%d) = %v, want between %v and %v", attempt, got, want/2, want)
+			}
+		}
+	}
+}
+
+func TestIsRetryableAIError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{&ProviderError{Provider: "openai", StatusCode: http.StatusTooManyRequests}, true},
+		{&ProviderError{Provider: "openai", StatusCode: http.StatusRequestTimeout}, true},
+		{&ProviderError{Provider: "openai", StatusCode: http.StatusBadGateway}, true},
+		{fmt.Errorf("match: %w", &ProviderError{Provider: "openai", StatusCode: http.StatusServiceUnavailable}), true},
+		{&ProviderError{Provider: "openai", StatusCode: http.StatusUnauthorized}, false},
+		{&ProviderError{Provider: "openai", StatusCode: http.StatusBadRequest}, false},
+		{&net.DNSError{Err: "timeout", IsTimeout: true}, true},
+		{&net.DNSError{Err: "no such host", IsNotFound: true}, false},
+		{context.DeadlineExceeded, true},
+		{fmt.Errorf("read body: %w", io.ErrUnexpectedEOF), true},
+		{io.EOF, true},
+		{context.Canceled, false},
+		{errors.New("invalid API key"), false},
+	}
+	for _, tt := range tests {
+		if got := isRetryableAIError(tt.err); got != tt.want {
+			t.Errorf("isRetryableAIError(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestSleepContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := sleepContext(ctx, time.Hour); !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled sleep = %v", err)
+	}
+	if err := sleepContext(context.Background(), time.Millisecond); err != nil {
+		t.Errorf("sleep = %v", err)
+	}
+}
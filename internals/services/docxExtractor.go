@@ -0,0 +1,174 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const wordprocessingNS = "http://schemas.openxmlformats.org/wordprocessingml/2006/main"
+const markupCompatibilityNS = "http://schemas.openxmlformats.org/markup-compatibility/2006"
+
+var (
+	docxHeaderPart = regexp.MustCompile(`^word/header(\d*)\.xml$`)
+	docxFooterPart = regexp.MustCompile(`^word/footer(\d*)\.xml$`)
+)
+
+// extractDOCXText reads an OOXML (.docx) package and returns its plain text.
+// Headers come first, then the document body, then footers. Paragraphs are
+// emitted in document order, one per line, including paragraphs nested in
+// tables and text boxes. Footnotes and comments are ignored.
+func extractDOCXText(content []byte) (string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return "", errors.New("invalid DOCX file: " + err.Error())
+	}
+
+	var body *zip.File
+	var headers, footers []*zip.File
+	for _, f := range zr.File {
+		switch {
+		case f.Name == "word/document.xml":
+			body = f
+		case docxHeaderPart.MatchString(f.Name):
+			headers = append(headers, f)
+		case docxFooterPart.MatchString(f.Name):
+			footers = append(footers, f)
+		}
+	}
+
+	if body == nil {
+		return "", errors.New("invalid DOCX file: word/document.xml not found")
+	}
+
+	sortDOCXParts(headers)
+	sortDOCXParts(footers)
+
+	var text strings.Builder
+	seen := make(map[string]bool)
+	parts := append(append(headers, body), footers...)
+	for _, part := range parts {
+		partText, err := extractDOCXPart(part)
+		if err != nil {
+			return "", err
+		}
+		// Sections often repeat the same header (first page, even/odd pages).
+		if partText == "" || seen[partText] {
+			continue
+		}
+		seen[partText] = true
+		text.WriteString(partText)
+		text.WriteString("\n")
+	}
+
+	return text.String(), nil
+}
+
+// sortDOCXParts orders header/footer parts by their numeric suffix so that
+// header2.xml comes before header10.xml.
+func sortDOCXParts(parts []*zip.File) {
+	sort.Slice(parts, func(i, j int) bool {
+		if len(parts[i].Name) != len(parts[j].Name) {
+			return len(parts[i].Name) < len(parts[j].Name)
+		}
+		return parts[i].Name < parts[j].Name
+	})
+}
+
+func extractDOCXPart(part *zip.File) (string, error) {
+	rc, err := part.Open()
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+
+	return extractWordprocessingText(rc)
+}
+
+// extractWordprocessingText walks a WordprocessingML part token by token.
+// Text boxes are stored twice (a DrawingML choice and a VML fallback), so
+// everything under mc:Fallback is skipped to avoid duplicated content.
+func extractWordprocessingText(r io.Reader) (string, error) {
+	decoder := xml.NewDecoder(r)
+
+	var out strings.Builder
+	var line strings.Builder
+	inText := false
+	fallbackDepth := 0
+
+	flushLine := func() {
+		trimmed := strings.TrimRight(line.String(), " \t")
+		line.Reset()
+		if strings.TrimSpace(trimmed) == "" {
+			return
+		}
+		out.WriteString(trimmed)
+		out.WriteString("\n")
+	}
+
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", errors.New("invalid DOCX XML: " + err.Error())
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Space == markupCompatibilityNS && t.Name.Local == "Fallback" {
+				fallbackDepth++
+				continue
+			}
+			if fallbackDepth > 0 || t.Name.Space != wordprocessingNS {
+				continue
+			}
+
+			switch t.Name.Local {
+			case "t":
+				inText = true
+			case "tab", "ptab":
+				line.WriteString("\t")
+			case "br", "cr":
+				flushLine()
+			case "p":
+				// A paragraph nested in a text box starts while the outer
+				// paragraph is still open; keep them on separate lines.
+				flushLine()
+			}
+
+		case xml.EndElement:
+			if t.Name.Space == markupCompatibilityNS && t.Name.Local == "Fallback" {
+				fallbackDepth--
+				continue
+			}
+			if fallbackDepth > 0 || t.Name.Space != wordprocessingNS {
+				continue
+			}
+
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				// Table cells hold their own paragraphs, so each cell
+				// ends up on its own line in row order.
+				flushLine()
+			}
+
+		case xml.CharData:
+			if inText && fallbackDepth == 0 {
+				line.Write(t)
+			}
+		}
+	}
+
+	flushLine()
+
+	return strings.TrimRight(out.String(), "\n"), nil
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// buildDOCX zips the given parts into an in-memory .docx package.
+func buildDOCX(t *testing.T, parts map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range parts {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// wordPart wraps body XML in a WordprocessingML root element.
+func wordPart(root, body string) string {
+	return `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		`<w:` + root + ` xmlns:w="` + wordprocessingNS + `" xmlns:mc="` + markupCompatibilityNS + `">` +
+		body + `</w:` + root + `>`
+}
+
+func paragraph(text string) string {
+	return `<w:p><w:r><w:t>` + text + `</w:t></w:r></w:p>`
+}
+
+func TestExtractDOCXText(t *testing.T) {
+	tests := []struct {
+		name  string
+		parts map[string]string
+		want  string
+	}{
+		{
+			name: "paragraphs, runs, tabs and breaks",
+			parts: map[string]string{
+				"word/document.xml": wordPart("document", `<w:body>`+
+					`<w:p><w:r><w:t>Jane</w:t></w:r><w:r><w:t xml:space="preserve"> Doe</w:t></w:r></w:p>`+
+					`<w:p><w:r><w:t>Go</w:t><w:tab/><w:t>Docker</w:t><w:br/><w:t>Berlin</w:t></w:r></w:p>`+
+					`<w:p></w:p>`+
+					`</w:body>`),
+			},
+			want: "Jane Doe\nGo\tDocker\nBerlin",
+		},
+		{
+			name: "table cells in row order",
+			parts: map[string]string{
+				"word/document.xml": wordPart("document", `<w:body><w:tbl>`+
+					`<w:tr><w:tc>`+paragraph("Skills")+`</w:tc><w:tc>`+paragraph("Go, SQL")+`</w:tc></w:tr>`+
+					`<w:tr><w:tc>`+paragraph("Languages")+`</w:tc><w:tc>`+paragraph("English")+`</w:tc></w:tr>`+
+					`</w:tbl></w:body>`),
+			},
+			want: "Skills\nGo, SQL\nLanguages\nEnglish",
+		},
+		{
+			name: "text box without its VML fallback",
+			parts: map[string]string{
+				"word/document.xml": wordPart("document", `<w:body><w:p><w:r><w:t>Summary</w:t></w:r>`+
+					`<mc:AlternateContent>`+
+					`<mc:Choice Requires="wps"><w:txbxContent>`+paragraph("Open to relocation")+`</w:txbxContent></mc:Choice>`+
+					`<mc:Fallback><w:txbxContent>`+paragraph("Open to relocation")+`</w:txbxContent></mc:Fallback>`+
+					`</mc:AlternateContent></w:p></w:body>`),
+			},
+			want: "Summary\nOpen to relocation",
+		},
+		{
+			name: "headers and footers around the body, repeats dropped",
+			parts: map[string]string{
+				"word/document.xml": wordPart("document", `<w:body>`+paragraph("Experience")+`</w:body>`),
+				"word/header10.xml": wordPart("hdr", paragraph("Page header")),
+				"word/header2.xml":  wordPart("hdr", paragraph("jane@example.com")),
+				"word/header3.xml":  wordPart("hdr", paragraph("jane@example.com")),
+				"word/footer1.xml":  wordPart("ftr", paragraph("References on request")),
+			},
+			want: "jane@example.com\nPage header\nExperience\nReferences on request",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := extractDOCXText(buildDOCX(t, tt.parts))
+			if err != nil {
+				t.Fatalf("extractDOCXText: %v", err)
+			}
+			if got = strings.TrimRight(got, "\n"); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractDOCXTextErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content []byte
+		want    string
+	}{
+		{"not a zip", []byte("plain text"), "invalid DOCX file"},
+		{"no document part", buildDOCX(t, map[string]string{"word/styles.xml": "<styles/>"}), "word/document.xml not found"},
+		{"broken XML", buildDOCX(t, map[string]string{"word/document.xml": "<w:document"}), "invalid DOCX XML"},
+	}
+	for _, tt := range tests {
+		if _, err := extractDOCXText(tt.content); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: error = %v, want it to mention %q", tt.name, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package services
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+)
+
+// EducationLevel is a rung on the degree ladder. Higher levels imply the
+// lower ones, so a PhD satisfies a bachelor requirement.
+type EducationLevel int
+
+const (
+	EducationUnknown EducationLevel = iota
+	EducationHighSchool
+	EducationAssociate
+	EducationBachelor
+	EducationMaster
+	EducationPhD
+)
+
+var educationLevelNames = map[EducationLevel]string{
+	EducationHighSchool: "high_school",
+	EducationAssociate:  "associate",
+	EducationBachelor:   "bachelor",
+	EducationMaster:     "master",
+	EducationPhD:        "phd",
+}
+
+// String returns the level name used in AI replies and API output, or an
+// empty string for EducationUnknown.
+func (l EducationLevel) String() string {
+	return educationLevelNames[l]
+}
+
+// educationSynonyms maps normalized degree names and abbreviations to their
+// level. Keys are lowercase with dots and apostrophes removed, so "M.S.",
+// "MS" and "ms" all become "ms". Multi-word keys match consecutive words.
+var educationSynonyms = map[string]EducationLevel{
+	"phd": EducationPhD, "dphil": EducationPhD, "doctorate": EducationPhD, "doctoral": EducationPhD,
+	"doctor of": EducationPhD, "dr": EducationPhD, "edd": EducationPhD, "dsc": EducationPhD,
+
+	"master": EducationMaster, "masters": EducationMaster, "ms": EducationMaster, "msc": EducationMaster,
+	"ma": EducationMaster, "mtech": EducationMaster, "me": EducationMaster, "meng": EducationMaster,
+	"mba": EducationMaster, "mca": EducationMaster, "mphil": EducationMaster, "mres": EducationMaster,
+	"mcom": EducationMaster, "llm": EducationMaster, "mfa": EducationMaster, "diplom": EducationMaster,
+
+	"bachelor": EducationBachelor, "bachelors": EducationBachelor, "bs": EducationBachelor,
+	"bsc": EducationBachelor, "ba": EducationBachelor, "btech": EducationBachelor, "be": EducationBachelor,
+	"beng": EducationBachelor, "bca": EducationBachelor, "bcom": EducationBachelor, "bba": EducationBachelor,
+	"llb": EducationBachelor, "bfa": EducationBachelor, "undergraduate": EducationBachelor,
+
+	"associate": EducationAssociate, "associates": EducationAssociate, "aa": EducationAssociate,
+	"as": EducationAssociate, "aas": EducationAssociate, "foundation degree": EducationAssociate,
+	"hnd": EducationAssociate,
+
+	"high school": EducationHighSchool, "secondary school": EducationHighSchool,
+	"higher secondary": EducationHighSchool, "hsc": EducationHighSchool, "ssc": EducationHighSchool,
+	"ged": EducationHighSchool, "a levels": EducationHighSchool, "a level": EducationHighSchool,
+	"abitur": EducationHighSchool, "baccalaureate": EducationHighSchool,
+}
+
+// ambiguousDegreeWords are abbreviations that are also ordinary words. They
+// only count when written as an abbreviation: dotted ("B.E.") or in capitals
+// ("BE").
+var ambiguousDegreeWords = map[string]bool{
+	"ma": true, "me": true, "be": true, "as": true, "aa": true, "dr": true,
+}
+
+// contextualDegreeWords are abbreviations that are also common outside
+// degrees even in capitals, like "MS" in "MS Office". In free text they
+// only count when dotted ("M.S."), followed by a word from
+// degreeContextWords ("MS in Physics") or standing alone ("MS"); in a degree
+// field they always count.
+var contextualDegreeWords = map[string]bool{
+	"ms": true,
+}
+
+var degreeContextWords = map[string]bool{
+	"in": true, "of": true, "degree": true, "degrees": true, "program": true, "programme": true,
+}
+
+const maxDegreePhraseWords = 2
+
+type degreeWord struct {
+	norm         string
+	abbreviation bool // written dotted or in capitals
+	dotted       bool
+}
+
+// ParseEducationLevel returns the highest degree level mentioned in text.
+func ParseEducationLevel(text string) EducationLevel {
+	return highestLevel(parseEducationLevels(text, false))
+}
+
+// ParseRequiredEducationLevel returns the lowest degree level mentioned in
+// a requirement, so "BS/MS in Computer Science" or "Bachelor's required,
+// Master's preferred" ask for a bachelor's degree.
+func ParseRequiredEducationLevel(text string) EducationLevel {
+	return lowestLevel(parseEducationLevels(text, false))
+}
+
+// HighestEducationLevel returns the highest level across a resume's degrees.
+func HighestEducationLevel(educations []models.Education) EducationLevel {
+	best := EducationUnknown
+	for _, edu := range educations {
+		if level := highestLevel(parseEducationLevels(edu.Degree, true)); level > best {
+			best = level
+		}
+	}
+	return best
+}
+
+// parseEducationLevels returns every degree level mentioned in text, in
+// order. degreeField is set when the whole text names a degree, which
+// resolves contextualDegreeWords.
+func parseEducationLevels(text string, degreeField bool) []EducationLevel {
+	words := degreeWords(text)
+	var levels []EducationLevel
+
+	for i := range words {
+		for n := maxDegreePhraseWords; n >= 1; n-- {
+			if i+n > len(words) {
+				continue
+			}
+			parts := make([]string, n)
+			for k := 0; k < n; k++ {
+				parts[k] = words[i+k].norm
+			}
+			key := strings.Join(parts, " ")
+
+			level, ok := educationSynonyms[key]
+			if !ok {
+				continue
+			}
+			if n == 1 && ambiguousDegreeWords[key] && !words[i].abbreviation {
+				continue
+			}
+			if n == 1 && contextualDegreeWords[key] && !degreeField && len(words) > 1 &&
+				!words[i].dotted && (i+1 >= len(words) || !degreeContextWords[words[i+1].norm]) {
+				continue
+			}
+			levels = append(levels, level)
+			break
+		}
+	}
+
+	return levels
+}
+
+func highestLevel(levels []EducationLevel) EducationLevel {
+	best := EducationUnknown
+	for _, level := range levels {
+		if level > best {
+			best = level
+		}
+	}
+	return best
+}
+
+func lowestLevel(levels []EducationLevel) EducationLevel {
+	lowest := EducationUnknown
+	for _, level := range levels {
+		if lowest == EducationUnknown || level < lowest {
+			lowest = level
+		}
+	}
+	return lowest
+}
+
+// meetsEducationRequirement compares the candidate's degrees with a job
+// requirement on the ladder. When the requirement names no known level it
+// falls back to a plain substring match on the degree names.
+func meetsEducationRequirement(educations []models.Education, required string) bool {
+	want := ParseRequiredEducationLevel(required)
+	if want == EducationUnknown {
+		for _, edu := range educations {
+			if strings.Contains(strings.ToLower(edu.Degree), strings.ToLower(required)) {
+				return true
+			}
+		}
+		return false
+	}
+	return HighestEducationLevel(educations) >= want
+}
+
+func degreeWords(text string) []degreeWord {
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '.' && r != '\'' && r != '’'
+	})
+
+	words := make([]degreeWord, 0, len(fields))
+	for _, field := range fields {
+		norm := strings.ToLower(strings.NewReplacer(".", "", "'", "", "’", "").Replace(field))
+		if norm == "" {
+			continue
+		}
+		words = append(words, degreeWord{
+			norm:         norm,
+			abbreviation: isAbbreviation(field),
+			dotted:       strings.Contains(strings.TrimSuffix(field, "."), "."),
+		})
+	}
+	return words
+}
+
+// isAbbreviation reports whether a word is written like "B.E.", "BE" or
+// "Dr.", as opposed to the plain words "be" or "dr".
+func isAbbreviation(word string) bool {
+	if strings.Contains(strings.TrimSuffix(word, "."), ".") || isUpperWord(word) {
+		return true
+	}
+	first := []rune(word)[0]
+	return strings.HasSuffix(word, ".") && unicode.IsUpper(first)
+}
+
+func isUpperWord(word string) bool {
+	hasLetter := false
+	for _, r := range word {
+		if unicode.IsLetter(r) {
+			if !unicode.IsUpper(r) {
+				return false
+			}
+			hasLetter = true
+		}
+	}
+	return hasLetter
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+)
+
+func TestParseEducationLevel(t *testing.T) {
+	tests := []struct {
+		text string
+		want EducationLevel
+	}{
+		{"B.Sc. Computer Science", EducationBachelor},
+		{"Master's degree in Physics", EducationMaster},
+		{"PhD, Stanford University", EducationPhD},
+		{"BS/MS in Computer Science", EducationMaster},
+		{"M.S. Electrical Engineering", EducationMaster},
+		{"MS", EducationMaster},
+		{"Advanced MS Office and MS Excel skills", EducationUnknown},
+		{"I would be happy to relocate", EducationUnknown},
+		{"Worked as a consultant", EducationUnknown},
+		{"", EducationUnknown},
+	}
+	for _, tt := range tests {
+		if got := ParseEducationLevel(tt.text); got != tt.want {
+			t.Errorf("ParseEducationLevel(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestParseRequiredEducationLevel(t *testing.T) {
+	tests := []struct {
+		text string
+		want EducationLevel
+	}{
+		{"BS/MS in Computer Science", EducationBachelor},
+		{"Bachelor's required, Master's preferred", EducationBachelor},
+		{"PhD or MSc", EducationMaster},
+		{"MSc", EducationMaster},
+		{"Proficiency with MS Office", EducationUnknown},
+	}
+	for _, tt := range tests {
+		if got := ParseRequiredEducationLevel(tt.text); got != tt.want {
+			t.Errorf("ParseRequiredEducationLevel(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestHighestEducationLevel(t *testing.T) {
+	educations := []models.Education{
+		{Degree: "BSc Mathematics"},
+		{Degree: "MS Statistics"},
+	}
+	if got := HighestEducationLevel(educations); got != EducationMaster {
+		t.Errorf("got %v, want master", got)
+	}
+	if got := HighestEducationLevel(nil); got != EducationUnknown {
+		t.Errorf("no degrees: got %v, want unknown", got)
+	}
+}
+
+func TestMeetsEducationRequirement(t *testing.T) {
+	bachelor := []models.Education{{Degree: "B.Tech Computer Science"}}
+	master := []models.Education{{Degree: "M.Sc. Data Science"}}
+
+	tests := []struct {
+		name       string
+		educations []models.Education
+		required   string
+		want       bool
+	}{
+		{"bachelor meets BS/MS", bachelor, "BS/MS in Computer Science", true},
+		{"master meets bachelor", master, "Bachelor's degree", true},
+		{"bachelor short of master", bachelor, "Master's degree required", false},
+		{"unknown requirement falls back to substring", bachelor, "Computer Science", true},
+		{"no degree", nil, "Bachelor's degree", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := meetsEducationRequirement(tt.educations, tt.required); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDegreeLevelKnockout(t *testing.T) {
+	rule := models.KnockoutRule{Type: KnockoutDegreeLevel, Value: "BS/MS"}
+	resume := &models.Resume{Education: []models.Education{{Degree: "BSc Physics"}}}
+	if _, ok := evaluateKnockout(rule, resume); !ok {
+		t.Error("a bachelor's degree failed a BS/MS requirement")
+	}
+
+	rule.Value = "MS"
+	if _, ok := evaluateKnockout(rule, resume); ok {
+		t.Error("a bachelor's degree passed an MS requirement")
+	}
+}
@@ -0,0 +1,198 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+)
+
+// employmentGapThreshold is the shortest break between jobs, in months, that
+// is reported as an employment gap.
+const employmentGapThreshold = 3
+
+var (
+	monthPattern     = `(?:jan(?:uary)?|feb(?:ruary)?|mar(?:ch)?|apr(?:il)?|may|june?|july?|aug(?:ust)?|sep(?:t(?:ember)?)?|oct(?:ober)?|nov(?:ember)?|dec(?:ember)?)\.?`
+	datePointPattern = `(?:` + monthPattern + `\s*,?\s*(?:19|20)\d{2}|\d{1,2}[/.-](?:19|20)\d{2}|(?:19|20)\d{2})`
+	dateRangeRegex   = regexp.MustCompile(`(?i)\b(` + datePointPattern + `)\s*(?:-|–|—|to|until)\s*(` + datePointPattern + `|present|current|now|today|date)\b`)
+
+	monthNameRegex  = regexp.MustCompile(`(?i)^(` + monthPattern + `)\s*,?\s*(\d{4})$`)
+	numericDate     = regexp.MustCompile(`^(\d{1,2})[/.-](\d{4})$`)
+	yearOnlyDate    = regexp.MustCompile(`^(\d{4})$`)
+	yearsDuration   = regexp.MustCompile(`(?i)(\d+(?:\.\d+)?)\s*(?:years?|yrs?)\b`)
+	monthsDuration  = regexp.MustCompile(`(?i)(\d+)\s*(?:months?|mos?)\b`)
+	monthByPrefix   = map[string]int{"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6, "jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12}
+	openEndedTokens = map[string]bool{"present": true, "current": true, "now": true, "today": true, "date": true}
+)
+
+// ExperienceSummary is the result of reading every Experience.Duration on a
+// resume. Dated ranges are merged so overlapping jobs are only counted once;
+// undated durations such as "2 yrs 4 mos" are added on top.
+type ExperienceSummary struct {
+	TotalMonths int                    `json:"total_months"`
+	Gaps        []models.EmploymentGap `json:"gaps"`
+}
+
+// Years returns the total experience in fractional years.
+func (s ExperienceSummary) Years() float64 {
+	return float64(s.TotalMonths) / 12
+}
+
+// monthSpan is a half-open range of month indexes (year*12 + month-1).
+type monthSpan struct {
+	start int
+	end   int
+}
+
+// SummarizeExperience computes total months of experience and employment
+// gaps from the Duration fields of the given experiences.
+func SummarizeExperience(experiences []models.Experience) ExperienceSummary {
+	return summarizeExperienceAt(experiences, time.Now())
+}
+
+func summarizeExperienceAt(experiences []models.Experience, now time.Time) ExperienceSummary {
+	var spans []monthSpan
+	undatedMonths := 0
+
+	for _, exp := range experiences {
+		if span, ok := parseDateRange(exp.Duration, now); ok {
+			spans = append(spans, span)
+			continue
+		}
+		undatedMonths += parseExplicitDuration(exp.Duration)
+	}
+
+	merged := mergeMonthSpans(spans)
+
+	summary := ExperienceSummary{TotalMonths: undatedMonths}
+	for i, span := range merged {
+		summary.TotalMonths += span.end - span.start
+		if i == 0 {
+			continue
+		}
+		if gap := span.start - merged[i-1].end; gap >= employmentGapThreshold {
+			summary.Gaps = append(summary.Gaps, models.EmploymentGap{
+				From:   formatMonthIndex(merged[i-1].end),
+				To:     formatMonthIndex(span.start - 1),
+				Months: gap,
+			})
+		}
+	}
+
+	return summary
+}
+
+// parseDateRange reads ranges like "Jan 2019 – Present", "03/2017 - 11/2020"
+// and "2015–2018". Month-precision ends are inclusive; a year-only end is
+// read as the start of that year, so "2015–2018" is three years.
+func parseDateRange(duration string, now time.Time) (monthSpan, bool) {
+	match := dateRangeRegex.FindStringSubmatch(duration)
+	if match == nil {
+		return monthSpan{}, false
+	}
+
+	start, _, ok := parseDatePoint(match[1])
+	if !ok {
+		return monthSpan{}, false
+	}
+
+	var end int
+	if openEndedTokens[strings.ToLower(match[2])] {
+		end = now.Year()*12 + int(now.Month())
+	} else {
+		point, yearOnly, ok := parseDatePoint(match[2])
+		if !ok {
+			return monthSpan{}, false
+		}
+		end = point + 1
+		if yearOnly {
+			end = point
+		}
+	}
+
+	if end <= start {
+		end = start + 1
+	}
+
+	return monthSpan{start: start, end: end}, true
+}
+
+// parseDatePoint returns the month index of a single date and whether only
+// the year was given.
+func parseDatePoint(value string) (int, bool, bool) {
+	value = strings.TrimSpace(value)
+
+	if m := monthNameRegex.FindStringSubmatch(value); m != nil {
+		month := monthByPrefix[strings.ToLower(m[1][:3])]
+		year, _ := strconv.Atoi(m[2])
+		return year*12 + month - 1, false, true
+	}
+
+	if m := numericDate.FindStringSubmatch(value); m != nil {
+		month, _ := strconv.Atoi(m[1])
+		year, _ := strconv.Atoi(m[2])
+		if month < 1 || month > 12 {
+			return 0, false, false
+		}
+		return year*12 + month - 1, false, true
+	}
+
+	if m := yearOnlyDate.FindStringSubmatch(value); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		return year * 12, true, true
+	}
+
+	return 0, false, false
+}
+
+// parseExplicitDuration reads undated durations like "2 yrs 4 mos",
+// "3 years" or "18 months" and returns the number of months.
+func parseExplicitDuration(duration string) int {
+	months := 0.0
+
+	if m := yearsDuration.FindStringSubmatch(duration); m != nil {
+		if years, err := strconv.ParseFloat(m[1], 64); err == nil {
+			months += years * 12
+		}
+	}
+	if m := monthsDuration.FindStringSubmatch(duration); m != nil {
+		if n, err := strconv.Atoi(m[1]); err == nil {
+			months += float64(n)
+		}
+	}
+
+	return int(math.Round(months))
+}
+
+func mergeMonthSpans(spans []monthSpan) []monthSpan {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	sort.Slice(spans, func(i, j int) bool {
+		return spans[i].start < spans[j].start
+	})
+
+	merged := []monthSpan{spans[0]}
+	for _, span := range spans[1:] {
+		last := &merged[len(merged)-1]
+		if span.start <= last.end {
+			if span.end > last.end {
+				last.end = span.end
+			}
+			continue
+		}
+		merged = append(merged, span)
+	}
+
+	return merged
+}
+
+func formatMonthIndex(index int) string {
+	return fmt.Sprintf("%04d-%02d", index/12, index%12+1)
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+)
+
+var experienceNow = time.Date(2024, time.June, 15, 0, 0, 0, 0, time.UTC)
+
+func TestParseDateRange(t *testing.T) {
+	tests := []struct {
+		duration string
+		months   int
+		ok       bool
+	}{
+		{"Jan 2019 – Present", 66, true},
+		{"January 2019 to current", 66, true},
+		{"03/2017 - 11/2020", 45, true},
+		{"Mar 2020 - Mar 2020", 1, true},
+		{"2015–2018", 36, true},
+		{"2018 - 2018", 1, true},
+		{"13/2017 - 11/2020", 0, false},
+		{"2 yrs 4 mos", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		span, ok := parseDateRange(tt.duration, experienceNow)
+		if ok != tt.ok {
+			t.Errorf("parseDateRange(%q) ok = %v, want %v", tt.duration, ok, tt.ok)
+			continue
+		}
+		if got := span.end - span.start; ok && got != tt.months {
+			t.Errorf("parseDateRange(%q) = %d months, want %d", tt.duration, got, tt.months)
+		}
+	}
+}
+
+func TestParseExplicitDuration(t *testing.T) {
+	tests := []struct {
+		duration string
+		want     int
+	}{
+		{"2 yrs 4 mos", 28},
+		{"3 years", 36},
+		{"1.5 years", 18},
+		{"18 months", 18},
+		{"a while", 0},
+	}
+	for _, tt := range tests {
+		if got := parseExplicitDuration(tt.duration); got != tt.want {
+			t.Errorf("parseExplicitDuration(%q) = %d, want %d", tt.duration, got, tt.want)
+		}
+	}
+}
+
+func TestSummarizeExperience(t *testing.T) {
+	tests := []struct {
+		name      string
+		durations []string
+		months    int
+		gaps      []models.EmploymentGap
+	}{
+		{
+			name:      "overlapping jobs count once",
+			durations: []string{"Jan 2020 - Dec 2021", "Jun 2021 - Jun 2022"},
+			months:    30,
+		},
+		{
+			name:      "gap between jobs",
+			durations: []string{"Jun 2017 - Present", "Jan 2015 - Dec 2016"},
+			months:    109,
+			gaps:      []models.EmploymentGap{{From: "2017-01", To: "2017-05", Months: 5}},
+		},
+		{
+			name:      "short break is not a gap",
+			durations: []string{"Jan 2020 - Dec 2020", "Mar 2021 - Dec 2021"},
+			months:    22,
+		},
+		{
+			name:      "undated durations are added",
+			durations: []string{"Jan 2023 - Dec 2023", "2 yrs 4 mos"},
+			months:    40,
+		},
+		{
+			name:      "unreadable durations",
+			durations: []string{"", "a while"},
+			months:    0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var experiences []models.Experience
+			for _, duration := range tt.durations {
+				experiences = append(experiences, models.Experience{Duration: duration})
+			}
+
+			summary := summarizeExperienceAt(experiences, experienceNow)
+			if summary.TotalMonths != tt.months {
+				t.Errorf("TotalMonths = %d, want %d", summary.TotalMonths, tt.months)
+			}
+			if len(summary.Gaps) != len(tt.gaps) {
+				t.Fatalf("Gaps = %v, want %v", summary.Gaps, tt.gaps)
+			}
+			for i, gap := range summary.Gaps {
+				if gap != tt.gaps[i] {
+					t.Errorf("gap %d = %+v, want %+v", i, gap, tt.gaps[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"sync"
+)
+
+// FakeLLMProvider is a deterministic provider for tests. It returns the configured replies in order, repeating the
+// last one, and records every request it receives.
+type FakeLLMProvider struct {
+	mu       sync.Mutex
+	replies  []string
+	err      error
+	requests []LLMRequest
+}
+
+// NewFakeLLMProvider returns a provider that answers with the given replies.
+// Without replies it answers "{}".
+func NewFakeLLMProvider(replies ...string) *FakeLLMProvider {
+	return &FakeLLMProvider{replies: replies}
+}
+
+// FailWith makes every following call return err.
+func (f *FakeLLMProvider) FailWith(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.err = err
+}
+
+// Requests returns the requests received so far.
+func (f *FakeLLMProvider) Requests() []LLMRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]LLMRequest(nil), f.requests...)
+}
+
+func (f *FakeLLMProvider) Name() string {
+	return "fake"
+}
+
+func (f *FakeLLMProvider) Generate(ctx context.Context, req LLMRequest) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	call := len(f.requests)
+	f.requests = append(f.requests, req)
+
+	if f.err != nil {
+		return "", f.err
+	}
+	if len(f.replies) == 0 {
+		return "{}", nil
+	}
+	if call >= len(f.replies) {
+		call = len(f.replies) - 1
+	}
+	return f.replies[call], nil
+}
+
+func (f *FakeLLMProvider) Close() error {
+	return nil
+}
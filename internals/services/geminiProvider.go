@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+)
+
+// GeminiProvider sends prompts to Google's Gemini API.
+type GeminiProvider struct {
+	client *genai.Client
+}
+
+func NewGeminiProvider(apiKey string) (*GeminiProvider, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("gemini: AI_API_KEY is required")
+	}
+
+	client, err := genai.NewClient(context.Background(), option.WithAPIKey(apiKey))
+	if err != nil {
+		return nil, err
+	}
+
+	return &GeminiProvider{client: client}, nil
+}
+
+func (g *GeminiProvider) Name() string {
+	return "gemini"
+}
+
+func (g *GeminiProvider) Generate(ctx context.Context, req LLMRequest) (string, error) {
+	model := g.client.GenerativeModel(req.Model)
+	if req.JSON {
+		model.ResponseMIMEType = "application/json"
+	}
+
+	resp, err := model.GenerateContent(ctx, genai.Text(req.Prompt))
+	if err != nil {
+		var apiErr *googleapi.Error
+		if errors.As(err, &apiErr) {
+			return "", &ProviderError{Provider: g.Name(), StatusCode: apiErr.Code, Message: apiErr.Message}
+		}
+		return "", err
+	}
+
+	if len(resp.Candidates) == 0 || resp.Candidates[0].Content == nil {
+		return "", fmt.Errorf("no response from AI")
+	}
+
+	var text strings.Builder
+	for _, part := range resp.Candidates[0].Content.Parts {
+		if t, ok := part.(genai.Text); ok {
+			text.WriteString(string(t))
+		}
+	}
+
+	return text.String(), nil
+}
+
+func (g *GeminiProvider) Close() error {
+	return g.client.Close()
+}
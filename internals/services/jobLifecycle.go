@@ -0,0 +1,120 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidJobStatus           = errors.New("invalid job status")
+	ErrInvalidJobStatusTransition = errors.New("invalid job status change")
+	ErrInvalidJob                 = errors.New("invalid job")
+	ErrJobNotMatchable            = errors.New("closed and archived jobs cannot be matched")
+)
+
+var jobStatuses = []string{
+	models.JobStatusDraft,
+	models.JobStatusOpen,
+	models.JobStatusPaused,
+	models.JobStatusClosed,
+	models.JobStatusArchived,
+}
+
+// ValidateJobStatus checks that status is one of the job statuses.
+func ValidateJobStatus(status string) error {
+	if !slices.Contains(jobStatuses, status) {
+		return fmt.Errorf("%w %q: use one of %s", ErrInvalidJobStatus, status, strings.Join(jobStatuses, ", "))
+	}
+	return nil
+}
+
+// jobStatusTransitions lists the statuses each status may change to. A
+// published job cannot go back to draft, and archived is final.
+var jobStatusTransitions = map[string][]string{
+	models.JobStatusDraft:    {models.JobStatusOpen, models.JobStatusClosed, models.JobStatusArchived},
+	models.JobStatusOpen:     {models.JobStatusPaused, models.JobStatusClosed, models.JobStatusArchived},
+	models.JobStatusPaused:   {models.JobStatusOpen, models.JobStatusClosed, models.JobStatusArchived},
+	models.JobStatusClosed:   {models.JobStatusOpen, models.JobStatusArchived},
+	models.JobStatusArchived: {},
+}
+
+// ValidateJobStatusTransition checks that a job may change from one status
+// to another. Keeping the same status is always allowed.
+func ValidateJobStatusTransition(from, to string) error {
+	if from == to {
+		return nil
+	}
+	if err := ValidateJobStatus(to); err != nil {
+		return err
+	}
+	if !slices.Contains(jobStatusTransitions[from], to) {
+		return fmt.Errorf("%w: a %s job cannot become %s", ErrInvalidJobStatusTransition, from, to)
+	}
+	return nil
+}
+
+// ValidateJob checks the fields every job needs, whether it is created,
+// replaced or patched.
+func ValidateJob(job *models.JobDescription) error {
+	if strings.TrimSpace(job.Title) == "" {
+		return fmt.Errorf("%w: title is required", ErrInvalidJob)
+	}
+	if strings.TrimSpace(job.ExperienceLevel) == "" {
+		return fmt.Errorf("%w: experience_level is required", ErrInvalidJob)
+	}
+	if job.MinExperience < 0 {
+		return fmt.Errorf("%w: min_experience cannot be negative", ErrInvalidJob)
+	}
+	return ValidateJobStatus(job.Status)
+}
+
+// ParseJobStatuses parses a comma-separated status filter such as
+// "open,paused". An empty filter returns nil.
+func ParseJobStatuses(filter string) ([]string, error) {
+	var statuses []string
+	for _, status := range strings.Split(filter, ",") {
+		status = strings.ToLower(strings.TrimSpace(status))
+		if status == "" {
+			continue
+		}
+		if err := ValidateJobStatus(status); err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// JobMatchable reports whether candidates can still be matched to the job.
+func JobMatchable(job *models.JobDescription) bool {
+	return job.Status != models.JobStatusClosed && job.Status != models.JobStatusArchived
+}
+
+// JobRequirementsChanged reports whether an edit changed anything the
+// heuristic score depends on. Scoring profile changes are picked up by the
+// profile columns on the score, so they are not compared here.
+func JobRequirementsChanged(before, after *models.JobDescription) bool {
+	return !slices.Equal(before.RequiredSkills, after.RequiredSkills) ||
+		!slices.Equal(before.NiceToHaveSkills, after.NiceToHaveSkills) ||
+		before.MinExperience != after.MinExperience ||
+		before.EducationRequired != after.EducationRequired ||
+		!slices.EqualFunc(before.KnockoutRules, after.KnockoutRules, knockoutRuleEqual)
+}
+
+func knockoutRuleEqual(a, b models.KnockoutRule) bool {
+	return a.Type == b.Type && a.Value == b.Value && a.Years == b.Years &&
+		a.AllowRemote == b.AllowRemote && slices.Equal(a.Values, b.Values)
+}
+
+// MarkScoresStale flags the job's current scores as out of date. Stale
+// scores stay readable and are replaced by the next match run.
+func MarkScoresStale(db *gorm.DB, jobID string) error {
+	return db.Model(&models.CandidateScore{}).
+		Where("job_id = ? AND stale = ?", jobID, false).
+		Update("stale", true).Error
+}
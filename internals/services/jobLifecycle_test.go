@@ -0,0 +1,55 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+)
+
+func TestValidateJob(t *testing.T) {
+	valid := models.JobDescription{Title: "Backend Engineer", ExperienceLevel: "senior", MinExperience: 3, Status: models.JobStatusOpen}
+	if err := ValidateJob(&valid); err != nil {
+		t.Fatalf("valid job rejected: %v", err)
+	}
+
+	tests := map[string]func(job *models.JobDescription){
+		"blank title":            func(job *models.JobDescription) { job.Title = "  " },
+		"blank experience level": func(job *models.JobDescription) { job.ExperienceLevel = "" },
+		"negative experience":    func(job *models.JobDescription) { job.MinExperience = -1 },
+		"unknown status":         func(job *models.JobDescription) { job.Status = "deleted" },
+	}
+	for name, edit := range tests {
+		job := valid
+		edit(&job)
+		if err := ValidateJob(&job); err == nil {
+			t.Errorf("%s: accepted", name)
+		}
+	}
+}
+
+func TestValidateJobStatusTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		allowed  bool
+	}{
+		{models.JobStatusDraft, models.JobStatusOpen, true},
+		{models.JobStatusOpen, models.JobStatusPaused, true},
+		{models.JobStatusPaused, models.JobStatusOpen, true},
+		{models.JobStatusClosed, models.JobStatusOpen, true},
+		{models.JobStatusOpen, models.JobStatusArchived, true},
+		{models.JobStatusArchived, models.JobStatusArchived, true},
+		{models.JobStatusArchived, models.JobStatusOpen, false},
+		{models.JobStatusOpen, models.JobStatusDraft, false},
+		{models.JobStatusClosed, models.JobStatusPaused, false},
+	}
+	for _, tt := range tests {
+		err := ValidateJobStatusTransition(tt.from, tt.to)
+		if (err == nil) != tt.allowed {
+			t.Errorf("%s -> %s: err = %v, allowed %v", tt.from, tt.to, err, tt.allowed)
+		}
+		if err != nil && !errors.Is(err, ErrInvalidJobStatusTransition) {
+			t.Errorf("%s -> %s: err = %v, want ErrInvalidJobStatusTransition", tt.from, tt.to, err)
+		}
+	}
+}
@@ -1,13 +1,23 @@
 package services
 
 import (
+	"context"
+	"errors"
+	"log"
 	"strings"
+	"time"
 
 	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
 )
 
+// ScoringAlgorithmVersion is recorded on every score. Bump it whenever
+// MatchResumeToJob changes how scores are computed so incremental re-scoring
+// picks up every resume again.
+const ScoringAlgorithmVersion = "3"
+
 type JobMatcherService struct {
 	aiService *AIService
+	registry  *AIRegistry
 }
 
 func NewJobMatcherService(aiService *AIService) *JobMatcherService {
@@ -16,103 +26,215 @@ func NewJobMatcherService(aiService *AIService) *JobMatcherService {
 	}
 }
 
+// SetAIRegistry makes the matcher use the AI provider set in each job's
+// organization instead of the default service.
+func (j *JobMatcherService) SetAIRegistry(registry *AIRegistry) {
+	j.registry = registry
+}
+
+// aiFor returns the AI service that scores the job, or nil when the job is
+// scored without AI.
+func (j *JobMatcherService) aiFor(job *models.JobDescription) *AIService {
+	if j.registry != nil {
+		return j.registry.For(job.OrganizationID)
+	}
+	return j.aiService
+}
+
 func (j *JobMatcherService) MatchResumeToJob(resume *models.Resume, job *models.JobDescription) *models.CandidateScore {
+	now := time.Now()
 	score := &models.CandidateScore{
-		ResumeID: resume.ID,
-		JobID:    job.ID,
+		ResumeID:         resume.ID,
+		JobID:            job.ID,
+		OrganizationID:   job.OrganizationID,
+		AlgorithmVersion: ScoringAlgorithmVersion,
+		ScoredAt:         &now,
+	}
+
+	// Knockout rules are checked before scoring
+	if reasons := EvaluateKnockouts(resume, job); len(reasons) > 0 {
+		score.Disqualified = true
+		score.DisqualificationReasons = reasons
 	}
 
 	// Calculate required skills match
-	requiredMatch := j.calculateSkillMatch(resume.Skills, job.RequiredSkills)
+	requiredMatch, requiredSkills := matchSkills(resume.Skills, job.RequiredSkills)
 	score.RequiredMatch = requiredMatch
+	score.RequiredSkills = requiredSkills
 
 	// Calculate nice-to-have skills match
-	niceToHaveMatch := j.calculateSkillMatch(resume.Skills, job.NiceToHaveSkills)
+	niceToHaveMatch, niceToHaveSkills := matchSkills(resume.Skills, job.NiceToHaveSkills)
 	score.NiceToHaveMatch = niceToHaveMatch
+	score.NiceToHaveSkills = niceToHaveSkills
 
 	// Calculate experience match
-	experienceMatch := j.calculateExperienceMatch(resume.Experience, job.MinExperience)
+	experience := SummarizeExperience(resume.Experience)
+	experienceMatch := j.calculateExperienceMatch(experience, job.MinExperience)
 	score.ExperienceMatch = experienceMatch
+	score.ExperienceMonths = experience.TotalMonths
+	score.EmploymentGaps = experience.Gaps
 
 	// Calculate education match
 	educationMatch := j.calculateEducationMatch(resume.Education, job.EducationRequired)
 	score.EducationMatch = educationMatch
+	score.EducationLevel = HighestEducationLevel(resume.Education).String()
 
 	// Calculate overall score
-	overallScore := (requiredMatch * 0.4) + (niceToHaveMatch * 0.2) + (experienceMatch * 0.3) + (educationMatch * 0.1)
+	profile := scoringProfileFor(job)
+	overallScore := (requiredMatch * profile.RequiredWeight) + (niceToHaveMatch * profile.NiceToHaveWeight) +
+		(experienceMatch * profile.ExperienceWeight) + (educationMatch * profile.EducationWeight)
 	score.Score = int(overallScore * 100)
+	if profile.ID != "" {
+		score.ScoringProfileID = &profile.ID
+	}
+	score.ScoringProfileVersion = profile.Version
 
 	return score
 }
 
-func (j *JobMatcherService) MatchResumeToJobWithAI(resume *models.Resume, job *models.JobDescription) (*models.CandidateScore, *AIMatchResult, error) {
-	// Get traditional matching score
+// Scoring modes a recruiter can pick per match request.
+const (
+	ScoringModeHeuristic = "heuristic" // skill, experience and education rules only
+	ScoringModeAI        = "ai"        // the AI score on its own
+	ScoringModeHybrid    = "hybrid"    // heuristic and AI blended by the profile's AIWeight
+)
+
+var ErrUnknownScoringMode = errors.New("scoring mode must be heuristic, ai or hybrid")
+
+// ParseScoringMode validates a mode from a request. An empty mode picks
+// hybrid when AI is available for the job and heuristic otherwise.
+func (j *JobMatcherService) ParseScoringMode(mode string, job *models.JobDescription) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case "":
+		if j.AIEnabled(job) {
+			return ScoringModeHybrid, nil
+		}
+		return ScoringModeHeuristic, nil
+	case ScoringModeHeuristic:
+		return ScoringModeHeuristic, nil
+	case ScoringModeAI:
+		return ScoringModeAI, nil
+	case ScoringModeHybrid:
+		return ScoringModeHybrid, nil
+	}
+	return "", ErrUnknownScoringMode
+}
+
+// AIEnabled reports whether the matcher can score the job in the ai and
+// hybrid modes.
+func (j *JobMatcherService) AIEnabled(job *models.JobDescription) bool {
+	return j.aiFor(job) != nil
+}
+
+// Match checks the job's knockout rules and scores a resume in the given
+// mode. The heuristic breakdown is always filled in; in ai and hybrid mode
+// the AI result is stored alongside it. When the AI call fails, times out or
+// the provider's circuit breaker is open, the heuristic score is returned
+// and ScoringMode records that it was used.
+func (j *JobMatcherService) Match(ctx context.Context, mode string, resume *models.Resume, job *models.JobDescription) *models.CandidateScore {
 	score := j.MatchResumeToJob(resume, job)
+	score.ScoringMode = ScoringModeHeuristic
 
-	// Get AI-enhanced matching if AI service is available
-	if j.aiService != nil {
-		aiResult, err := j.aiService.EnhanceMatching(resume.ParsedText, job.Description)
-		if err == nil {
-			// Combine traditional and AI scores (weighted average)
-			traditionalScore := float64(score.Score)
-			aiScore := aiResult.Score
-			combinedScore := (traditionalScore * 0.6) + (aiScore * 0.4)
+	// Disqualified candidates keep their heuristic score for reference but
+	// are not worth an AI call.
+	aiService := j.aiFor(job)
+	if mode == ScoringModeHeuristic || aiService == nil || score.Disqualified {
+		return score
+	}
 
-			score.Score = int(combinedScore)
-			score.AIEnhanced = true
+	aiResult, err := aiService.EnhanceMatching(ctx, resume.ParsedText, jobMatchText(job))
+	if err != nil {
+		log.Printf("AI matching unavailable for resume %s, using heuristic score: %v", resume.ID, err)
+		return score
+	}
 
-			return score, aiResult, nil
-		}
+	applyAIResult(score, aiResult)
+	score.AIModel = aiService.modelName(aiOperationMatch)
+	score.ScoringMode = mode
+	if mode == ScoringModeAI {
+		score.Score = int(aiResult.Score)
+	} else {
+		// Combine traditional and AI scores (weighted average)
+		aiWeight := scoringProfileFor(job).AIWeight
+		score.Score = int((float64(score.Score) * (1 - aiWeight)) + (aiResult.Score * aiWeight))
 	}
 
-	return score, nil, nil
+	return score
 }
 
-func (j *JobMatcherService) calculateSkillMatch(resumeSkills, jobSkills []string) float64 {
-	if len(jobSkills) == 0 {
-		return 1.0
+// MatchResumeToJobWithAI scores a resume in hybrid mode.
+func (j *JobMatcherService) MatchResumeToJobWithAI(ctx context.Context, resume *models.Resume, job *models.JobDescription) (*models.CandidateScore, *AIMatchResult, error) {
+	score := j.Match(ctx, ScoringModeHybrid, resume, job)
+	if !score.AIEnhanced {
+		return score, nil, nil
 	}
 
-	matched := 0
-	for _, jobSkill := range jobSkills {
-		for _, resumeSkill := range resumeSkills {
-			if strings.Contains(strings.ToLower(resumeSkill), strings.ToLower(jobSkill)) ||
-			   strings.Contains(strings.ToLower(jobSkill), strings.ToLower(resumeSkill)) {
-				matched++
-				break
-			}
-		}
+	return score, &AIMatchResult{
+		Score:           score.AIScore,
+		Reasoning:       score.AIReasoning,
+		MatchedSkills:   score.AIMatchedSkills,
+		MissingSkills:   score.AIMissingSkills,
+		ExperienceMatch: score.AIExperienceMatch,
+		EducationMatch:  score.AIEducationMatch,
+	}, nil
+}
+
+func applyAIResult(score *models.CandidateScore, result *AIMatchResult) {
+	score.AIEnhanced = true
+	score.AIScore = result.Score
+	score.AIReasoning = result.Reasoning
+	score.AIMatchedSkills = result.MatchedSkills
+	score.AIMissingSkills = result.MissingSkills
+	score.AIExperienceMatch = result.ExperienceMatch
+	score.AIEducationMatch = result.EducationMatch
+}
+
+// scoringProfileFor returns the job's resolved profile, or the built-in
+// weights when none was loaded.
+func scoringProfileFor(job *models.JobDescription) *models.ScoringProfile {
+	if job.ScoringProfile != nil {
+		return job.ScoringProfile
 	}
+	profile := DefaultScoringProfile()
+	return &profile
+}
 
-	return float64(matched) / float64(len(jobSkills))
+// jobMatchText is the job text sent to the AI for matching. AICache hashes
+// the same text, so both must change together.
+func jobMatchText(job *models.JobDescription) string {
+	return job.Description
 }
 
-func (j *JobMatcherService) calculateExperienceMatch(experiences []models.Experience, minYears int) float64 {
-	totalYears := 0
-	for _, exp := range experiences {
-		// Simple parsing of duration - in production, use better parsing
-		if strings.Contains(exp.Duration, "year") {
-			// Extract number
-			// Placeholder
-			totalYears += 1
-		}
+func (j *JobMatcherService) calculateExperienceMatch(experience ExperienceSummary, minYears int) float64 {
+	if minYears <= 0 {
+		return 1.0
 	}
 
-	if totalYears >= minYears {
+	years := experience.Years()
+	if years >= float64(minYears) {
 		return 1.0
 	}
-	return float64(totalYears) / float64(minYears)
+	return years / float64(minYears)
 }
 
+// calculateEducationMatch scores the candidate's highest degree against the
+// required level: meeting or exceeding it scores 1, one level short scores
+// 0.5 and anything lower scores 0.
 func (j *JobMatcherService) calculateEducationMatch(educations []models.Education, requiredEducation string) float64 {
 	if requiredEducation == "" {
 		return 1.0
 	}
 
-	for _, edu := range educations {
-		if strings.Contains(strings.ToLower(edu.Degree), strings.ToLower(requiredEducation)) {
-			return 1.0
-		}
+	if meetsEducationRequirement(educations, requiredEducation) {
+		return 1.0
+	}
+
+	// A candidate with no recognised degree gets no partial credit, even
+	// when the requirement is the lowest rung.
+	want := ParseRequiredEducationLevel(requiredEducation)
+	have := HighestEducationLevel(educations)
+	if want != EducationUnknown && have != EducationUnknown && have == want-1 {
+		return 0.5
 	}
 
 	return 0.0
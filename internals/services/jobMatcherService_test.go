@@ -0,0 +1,79 @@
+package services
+
+import (
+	"math"
+	"testing"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+)
+
+func TestCalculateEducationMatch(t *testing.T) {
+	matcher := NewJobMatcherService(nil)
+	bachelor := []models.Education{{Degree: "BSc Physics"}}
+	associate := []models.Education{{Degree: "Associate of Arts"}}
+	unknown := []models.Education{{Degree: "Coursework in welding"}}
+
+	tests := []struct {
+		name       string
+		educations []models.Education
+		required   string
+		want       float64
+	}{
+		{"no requirement", nil, "", 1},
+		{"meets BS/MS", bachelor, "BS/MS in Computer Science", 1},
+		{"one level short", bachelor, "Master's degree", 0.5},
+		{"two levels short", associate, "Master's degree", 0},
+		{"unknown degree below high school requirement", unknown, "High school diploma", 0},
+		{"unknown degree below associate requirement", unknown, "Associate degree", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matcher.calculateEducationMatch(tt.educations, tt.required); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculateExperienceMatch(t *testing.T) {
+	matcher := NewJobMatcherService(nil)
+	twoYears := ExperienceSummary{TotalMonths: 24}
+
+	for minYears, want := range map[int]float64{0: 1, 1: 1, 2: 1, 4: 0.5} {
+		if got := matcher.calculateExperienceMatch(twoYears, minYears); math.Abs(got-want) > 1e-9 {
+			t.Errorf("min %d years: got %v, want %v", minYears, got, want)
+		}
+	}
+}
+
+func TestMatchResumeToJobWeights(t *testing.T) {
+	matcher := NewJobMatcherService(nil)
+	resume := &models.Resume{
+		Skills:     []string{"Go", "PostgreSQL"},
+		Experience: []models.Experience{{Company: "Acme", Role: "Engineer", Duration: "Jan 2018 - Dec 2019"}},
+		Education:  []models.Education{{Degree: "BSc Computer Science"}},
+	}
+	job := &models.JobDescription{
+		RequiredSkills:    []string{"Go", "Kubernetes"},
+		NiceToHaveSkills:  []string{"PostgreSQL"},
+		MinExperience:     4,
+		EducationRequired: "Master's degree",
+	}
+
+	score := matcher.MatchResumeToJob(resume, job)
+
+	if score.RequiredMatch != 0.5 || score.NiceToHaveMatch != 1 {
+		t.Fatalf("skill match required %v nice-to-have %v", score.RequiredMatch, score.NiceToHaveMatch)
+	}
+	if score.ExperienceMatch != 0.5 || score.EducationMatch != 0.5 {
+		t.Fatalf("experience %v education %v", score.ExperienceMatch, score.EducationMatch)
+	}
+
+	// 0.5*0.4 + 1*0.2 + 0.5*0.3 + 0.5*0.1 with the default profile.
+	if score.Score != 60 {
+		t.Errorf("score = %d, want 60", score.Score)
+	}
+	if score.EducationLevel != "bachelor" || score.ScoringProfileVersion != 1 {
+		t.Errorf("education level %q profile version %d", score.EducationLevel, score.ScoringProfileVersion)
+	}
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+const (
+	// jobsVersionKey is bumped whenever a job or scoring profile changes,
+	// which retires every cached recommendation at once.
+	jobsVersionKey           = "jobs:version"
+	recommendationKeyPrefix  = "recommendations:"
+	recommendationCacheTTL   = time.Hour
+	defaultRecommendationMax = 10
+)
+
+// JobRecommendation is a job ranked for a resume, with the score breakdown
+// the job's own weights and knockout rules produced.
+type JobRecommendation struct {
+	Job   models.JobDescription `json:"job"`
+	Score models.CandidateScore `json:"score"`
+}
+
+// JobRecommendationService scores one resume against every job. Results are
+// cached in Redis per resume version and jobs version.
+type JobRecommendationService struct {
+	db       *gorm.DB
+	redis    *redis.Client
+	matcher  *JobMatcherService
+	profiles *ScoringProfileService
+}
+
+func NewJobRecommendationService(db *gorm.DB, redisClient *redis.Client, matcher *JobMatcherService, profiles *ScoringProfileService) *JobRecommendationService {
+	return &JobRecommendationService{
+		db:       db,
+		redis:    redisClient,
+		matcher:  matcher,
+		profiles: profiles,
+	}
+}
+
+// InvalidateJobs retires cached recommendations after a job or scoring
+// profile changed.
+func (s *JobRecommendationService) InvalidateJobs(ctx context.Context) {
+	if err := s.redis.Incr(ctx, jobsVersionKey).Err(); err != nil {
+		log.Printf("Failed to invalidate job recommendations: %v", err)
+	}
+}
+
+// Recommend returns the best open jobs for the resume, highest score first.
+// Jobs whose knockout rules the resume fails are left out unless
+// includeDisqualified is set. Scoring is heuristic, since running the AI
+// over every job would be too slow for an interactive request.
+func (s *JobRecommendationService) Recommend(ctx context.Context, resume *models.Resume, limit int, includeDisqualified bool) ([]JobRecommendation, error) {
+	if limit <= 0 {
+		limit = defaultRecommendationMax
+	}
+
+	ranked, err := s.ranked(ctx, resume)
+	if err != nil {
+		return nil, err
+	}
+
+	recommendations := make([]JobRecommendation, 0, limit)
+	for _, recommendation := range ranked {
+		if recommendation.Score.Disqualified && !includeDisqualified {
+			continue
+		}
+		recommendations = append(recommendations, recommendation)
+		if len(recommendations) == limit {
+			break
+		}
+	}
+	return recommendations, nil
+}
+
+// ranked returns every job scored for the resume, from cache when possible.
+func (s *JobRecommendationService) ranked(ctx context.Context, resume *models.Resume) ([]JobRecommendation, error) {
+	version, err := s.redis.Get(ctx, jobsVersionKey).Int64()
+	if err != nil && err != redis.Nil {
+		log.Printf("Failed to read jobs version: %v", err)
+	}
+	key := fmt.Sprintf("%s%s:%d:%d", recommendationKeyPrefix, resume.ID, resume.UpdatedAt.UnixNano(), version)
+
+	if data, err := s.redis.Get(ctx, key).Bytes(); err == nil {
+		var cached []JobRecommendation
+		if json.Unmarshal(data, &cached) == nil {
+			return cached, nil
+		}
+	}
+
+	var jobs []models.JobDescription
+	if err := s.db.WithContext(ctx).Preload("ScoringProfile").Where("status = ?", models.JobStatusOpen).Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+	if err := s.profiles.ResolveAll(jobs); err != nil {
+		return nil, err
+	}
+
+	ranked := make([]JobRecommendation, 0, len(jobs))
+	for i := range jobs {
+		score := s.matcher.Match(ctx, ScoringModeHeuristic, resume, &jobs[i])
+		job := jobs[i]
+		job.ScoringProfile = nil
+		ranked = append(ranked, JobRecommendation{Job: job, Score: *score})
+	}
+	sort.SliceStable(ranked, func(a, b int) bool {
+		return ranked[a].Score.Score > ranked[b].Score.Score
+	})
+
+	if data, err := json.Marshal(ranked); err == nil {
+		if err := s.redis.Set(ctx, key, data, recommendationCacheTTL).Err(); err != nil {
+			log.Printf("Failed to cache job recommendations: %v", err)
+		}
+	}
+
+	return ranked, nil
+}
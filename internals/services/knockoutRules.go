@@ -0,0 +1,144 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/taxonomy"
+)
+
+// Knockout rule types. A candidate failing any rule on a job is disqualified
+// before scoring.
+const (
+	KnockoutRequiredSkill = "required_skill" // Value: skill that must be on the resume
+	KnockoutMinYears      = "min_years"      // Years: minimum total experience
+	KnockoutDegreeLevel   = "degree_level"   // Value: minimum degree level, e.g. "bachelor" or "MSc"
+	KnockoutLocation      = "location"       // Values: accepted locations; AllowRemote accepts remote candidates
+	KnockoutCertification = "certification"  // Value: certification that must be listed
+	KnockoutKeyword       = "keyword"        // Value: phrase that must appear, e.g. a work authorization
+)
+
+var ErrInvalidKnockoutRule = errors.New("invalid knockout rule")
+
+// phrasePatterns caches the compiled pattern of every phrase containsPhrase
+// has looked for. Phrases come from the knockout rules of jobs, so the set
+// stays small.
+var phrasePatterns sync.Map
+
+// ValidateKnockoutRules checks that each rule has a known type and the
+// fields that type needs.
+func ValidateKnockoutRules(rules []models.KnockoutRule) error {
+	for i, rule := range rules {
+		switch rule.Type {
+		case KnockoutRequiredSkill, KnockoutDegreeLevel, KnockoutCertification, KnockoutKeyword:
+			if strings.TrimSpace(rule.Value) == "" {
+				return fmt.Errorf("%w %d: %s needs a value", ErrInvalidKnockoutRule, i, rule.Type)
+			}
+		case KnockoutMinYears:
+			if rule.Years <= 0 {
+				return fmt.Errorf("%w %d: min_years needs years greater than 0", ErrInvalidKnockoutRule, i)
+			}
+		case KnockoutLocation:
+			if len(rule.Values) == 0 && !rule.AllowRemote {
+				return fmt.Errorf("%w %d: location needs values or allow_remote", ErrInvalidKnockoutRule, i)
+			}
+		default:
+			return fmt.Errorf("%w %d: unknown type %q", ErrInvalidKnockoutRule, i, rule.Type)
+		}
+	}
+	return nil
+}
+
+// EvaluateKnockouts returns a reason for every rule on the job the resume
+// fails. An empty result means the candidate is eligible.
+func EvaluateKnockouts(resume *models.Resume, job *models.JobDescription) []string {
+	var reasons []string
+	for _, rule := range job.KnockoutRules {
+		if reason, ok := evaluateKnockout(rule, resume); !ok {
+			reasons = append(reasons, reason)
+		}
+	}
+	return reasons
+}
+
+func evaluateKnockout(rule models.KnockoutRule, resume *models.Resume) (string, bool) {
+	switch rule.Type {
+	case KnockoutRequiredSkill:
+		// Related skills earn partial credit when scoring but do not
+		// satisfy a must-have.
+		match := matchSkill(taxonomy.Default(), resolveSkills(resume.Skills), rule.Value)
+		if match.Kind == SkillMatchExact || match.Kind == SkillMatchAlias {
+			return "", true
+		}
+		return fmt.Sprintf("missing required skill %s", rule.Value), false
+
+	case KnockoutMinYears:
+		years := SummarizeExperience(resume.Experience).Years()
+		if years >= float64(rule.Years) {
+			return "", true
+		}
+		return fmt.Sprintf("has %.1f years of experience, %d required", years, rule.Years), false
+
+	case KnockoutDegreeLevel:
+		if meetsEducationRequirement(resume.Education, rule.Value) {
+			return "", true
+		}
+		return fmt.Sprintf("missing required degree %s", rule.Value), false
+
+	case KnockoutLocation:
+		// Only the location from the contact block counts; places mentioned
+		// elsewhere, such as a past employer's office, do not.
+		var location string
+		if resume.Location != nil {
+			location = *resume.Location
+		}
+		for _, accepted := range rule.Values {
+			if containsPhrase(location, accepted) {
+				return "", true
+			}
+		}
+		if rule.AllowRemote && containsPhrase(location, "remote") {
+			return "", true
+		}
+		if len(rule.Values) == 0 {
+			return "not eligible for remote work", false
+		}
+		return fmt.Sprintf("not located in %s", strings.Join(rule.Values, ", ")), false
+
+	case KnockoutCertification:
+		for _, cert := range resume.Certifications {
+			if strings.Contains(strings.ToLower(cert), strings.ToLower(rule.Value)) {
+				return "", true
+			}
+		}
+		return fmt.Sprintf("missing required certification %s", rule.Value), false
+
+	case KnockoutKeyword:
+		if containsPhrase(resume.ParsedText, rule.Value) {
+			return "", true
+		}
+		return fmt.Sprintf("resume does not mention %s", rule.Value), false
+	}
+
+	// Unknown rules are rejected when the job is saved; ignore stale ones.
+	return "", true
+}
+
+// containsPhrase reports whether phrase occurs in text as whole words,
+// ignoring case.
+func containsPhrase(text, phrase string) bool {
+	phrase = strings.TrimSpace(phrase)
+	if phrase == "" {
+		return false
+	}
+	key := strings.ToLower(phrase)
+	pattern, ok := phrasePatterns.Load(key)
+	if !ok {
+		pattern, _ = phrasePatterns.LoadOrStore(key, regexp.MustCompile(`(?i)(^|\W)`+regexp.QuoteMeta(phrase)+`($|\W)`))
+	}
+	return pattern.(*regexp.Regexp).MatchString(text)
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+)
+
+func TestLocationKnockout(t *testing.T) {
+	berlin := models.KnockoutRule{Type: KnockoutLocation, Values: []string{"Berlin"}}
+	remoteOnly := models.KnockoutRule{Type: KnockoutLocation, AllowRemote: true}
+
+	tests := []struct {
+		name     string
+		rule     models.KnockoutRule
+		location *string
+		text     string
+		eligible bool
+	}{
+		{"located in the city", berlin, ptr("Berlin, Germany"), "", true},
+		{"case and word boundaries", berlin, ptr("berlin"), "", true},
+		{"city only in the job history", berlin, ptr("Munich, Germany"), "Worked at a startup in Berlin", false},
+		{"no parsed location", berlin, nil, "Berlin", false},
+		{"part of another word", berlin, ptr("Berlingen, Switzerland"), "", false},
+		{"remote in the location", remoteOnly, ptr("Remote"), "", true},
+		{"remote only in the text", remoteOnly, ptr("Paris, France"), "Built remote monitoring tools", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resume := &models.Resume{Location: tt.location, ParsedText: tt.text}
+			_, ok := evaluateKnockout(tt.rule, resume)
+			if ok != tt.eligible {
+				t.Errorf("eligible = %v, want %v", ok, tt.eligible)
+			}
+		})
+	}
+}
+
+func TestKeywordKnockout(t *testing.T) {
+	rule := models.KnockoutRule{Type: KnockoutKeyword, Value: "work permit"}
+
+	for text, want := range map[string]bool{
+		"Holds an EU Work Permit.": true,
+		"work permits pending":     false,
+		"":                         false,
+	} {
+		if _, ok := evaluateKnockout(rule, &models.Resume{ParsedText: text}); ok != want {
+			t.Errorf("%q: eligible = %v, want %v", text, ok, want)
+		}
+	}
+}
+
+func TestMinYearsKnockout(t *testing.T) {
+	rule := models.KnockoutRule{Type: KnockoutMinYears, Years: 3}
+	resume := &models.Resume{Experience: []models.Experience{
+		{Company: "Acme", Role: "Engineer", Duration: "Jan 2018 - Dec 2019"},
+	}}
+
+	reason, ok := evaluateKnockout(rule, resume)
+	if ok {
+		t.Fatal("two years of experience passed a three year minimum")
+	}
+	if reason == "" {
+		t.Error("expected a reason")
+	}
+
+	rule.Years = 1
+	if _, ok := evaluateKnockout(rule, resume); !ok {
+		t.Error("two years of experience failed a one year minimum")
+	}
+}
+
+func TestValidateKnockoutRules(t *testing.T) {
+	valid := []models.KnockoutRule{
+		{Type: KnockoutRequiredSkill, Value: "Go"},
+		{Type: KnockoutMinYears, Years: 2},
+		{Type: KnockoutLocation, AllowRemote: true},
+	}
+	if err := ValidateKnockoutRules(valid); err != nil {
+		t.Errorf("valid rules rejected: %v", err)
+	}
+
+	for _, rule := range []models.KnockoutRule{
+		{Type: KnockoutRequiredSkill},
+		{Type: KnockoutMinYears},
+		{Type: KnockoutLocation},
+		{Type: "unknown", Value: "x"},
+	} {
+		if err := ValidateKnockoutRules([]models.KnockoutRule{rule}); err == nil {
+			t.Errorf("rule %+v accepted", rule)
+		}
+	}
+}
+
+func TestParseResumeLocation(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Jane Doe\nLocation: Berlin, Germany\njane@example.com\n\nExperience\nEngineer at Acme, Munich, Germany", "Berlin, Germany"},
+		{"Jane Doe\njane@example.com | +49 151 2345678 | Hamburg, Germany", "Hamburg, Germany"},
+		{"Jane Doe\nOpen to remote work\n\nSkills\nGo", "Open to remote work"},
+		{"Jane Doe\njane@example.com\n\nExperience\nAcme | Berlin, Germany", ""},
+	}
+	for _, tt := range tests {
+		if got := ParseResumeLocation(tt.text); got != tt.want {
+			t.Errorf("ParseResumeLocation(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
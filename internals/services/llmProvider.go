@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/config"
+)
+
+// LLMRequest is a single-turn prompt sent to a language model.
+type LLMRequest struct {
+	Model  string
+	Prompt string
+	// JSON asks the provider to constrain its output to a JSON object when
+	// the backend supports it.
+	JSON bool
+}
+
+// LLMProvider is a backend that can complete a prompt. AIService talks to
+// models only through this interface.
+type LLMProvider interface {
+	Name() string
+	Generate(ctx context.Context, req LLMRequest) (string, error)
+	Close() error
+}
+
+// ProviderError is returned when a provider answers with an HTTP error.
+type ProviderError struct {
+	Provider   string
+	StatusCode int
+	Message    string
+}
+
+func (e *ProviderError) Error() string {
+	return fmt.Sprintf("%s: status %d: %s", e.Provider, e.StatusCode, e.Message)
+}
+
+// defaultModels is used when no model is configured for a provider.
+var defaultModels = map[string]string{
+	"gemini": "gemini-1.5-flash",
+	"openai": "gpt-4o-mini",
+	"ollama": "llama3.1",
+}
+
+var llmHTTPClient = &http.Client{Timeout: 2 * time.Minute}
+
+// NewLLMProvider builds the named provider from the application config.
+func NewLLMProvider(name string, cfg config.Config) (LLMProvider, error) {
+	switch name {
+	case "gemini":
+		return NewGeminiProvider(cfg.AIAPIKey)
+	case "openai":
+		apiKey := cfg.OpenAIAPIKey
+		if apiKey == "" {
+			apiKey = cfg.AIAPIKey
+		}
+		return NewOpenAIProvider(cfg.OpenAIBaseURL, apiKey), nil
+	case "ollama":
+		return NewOllamaProvider(cfg.OllamaBaseURL), nil
+	default:
+		return nil, fmt.Errorf("unknown AI provider %q", name)
+	}
+}
@@ -0,0 +1,401 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/database"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	matchRunPollInterval = 2 * time.Second
+	// A running run whose worker has not sent a heartbeat for this long is
+	// assumed dead and put back in the queue.
+	matchRunStaleAfter = 10 * time.Minute
+	// Workers renew the heartbeat of the run they execute this often, well
+	// inside matchRunStaleAfter.
+	matchRunHeartbeatInterval = time.Minute
+
+	defaultAutoMatchTopN = 5
+)
+
+var (
+	ErrMatchRunNotFound  = errors.New("match run not found")
+	ErrMatchRunFinished  = errors.New("match run has already finished")
+	errMatchRunCancelled = errors.New("match run cancelled")
+	errMatchRunUnscoped  = errors.New("job has no organization")
+	errMatchRunJobGone   = errors.New("job was deleted")
+)
+
+// MatchRunService queues bulk match runs in Postgres and executes them on
+// worker goroutines, in the API process or in cmd/worker.
+type MatchRunService struct {
+	db          *gorm.DB
+	matcher     *JobMatcherService
+	profiles    *ScoringProfileService
+	concurrency int
+	batchSize   int
+}
+
+func NewMatchRunService(db *gorm.DB, matcher *JobMatcherService, profiles *ScoringProfileService, concurrency, batchSize int) *MatchRunService {
+	return &MatchRunService{
+		db:          db,
+		matcher:     matcher,
+		profiles:    profiles,
+		concurrency: concurrency,
+		batchSize:   batchSize,
+	}
+}
+
+// Matcher returns the matcher runs are scored with.
+func (s *MatchRunService) Matcher() *JobMatcherService {
+	return s.matcher
+}
+
+// Enqueue queues a run for the job. Unless full is set the run only scores
+// resumes whose current score is missing or out of date. Workers pick runs up in creation order.
+func (s *MatchRunService) Enqueue(job *models.JobDescription, mode string, full bool) (*models.MatchRun, error) {
+	return s.enqueue(&models.MatchRun{JobID: job.ID, OrganizationID: job.OrganizationID, Mode: mode, Full: full})
+}
+
+// EnqueueAutoMatch queues a run for a job with auto_match set. When it
+// finishes, the job's creator is notified of the top candidates.
+func (s *MatchRunService) EnqueueAutoMatch(job *models.JobDescription, topN int) (*models.MatchRun, error) {
+	mode, _ := s.matcher.ParseScoringMode("", job)
+	if topN <= 0 {
+		topN = defaultAutoMatchTopN
+	}
+	return s.enqueue(&models.MatchRun{
+		JobID:          job.ID,
+		OrganizationID: job.OrganizationID,
+		Mode:           mode,
+		NotifyTopN:     topN,
+		NotifyUserID:   job.CreatedBy,
+	})
+}
+
+func (s *MatchRunService) enqueue(run *models.MatchRun) (*models.MatchRun, error) {
+	run.Status = models.MatchRunQueued
+	run.Failures = []models.MatchFailure{}
+	if err := s.db.Create(run).Error; err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// Get returns a run. ctx limits the lookup to the caller's organization.
+func (s *MatchRunService) Get(ctx context.Context, id string) (*models.MatchRun, error) {
+	var run models.MatchRun
+	if err := s.db.WithContext(ctx).Where("id = ?", id).First(&run).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMatchRunNotFound
+		}
+		return nil, err
+	}
+	return &run, nil
+}
+
+// Cancel stops a run. A queued run is cancelled straight away; a running run
+// is flagged and its worker stops after the current batch.
+func (s *MatchRunService) Cancel(ctx context.Context, id string) (*models.MatchRun, error) {
+	db := s.db.WithContext(ctx)
+	now := time.Now()
+	res := db.Model(&models.MatchRun{}).
+		Where("id = ? AND status = ?", id, models.MatchRunQueued).
+		Updates(map[string]interface{}{"status": models.MatchRunCancelled, "cancel_requested": true, "finished_at": now})
+	if res.Error != nil {
+		return nil, res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		res = db.Model(&models.MatchRun{}).
+			Where("id = ? AND status = ?", id, models.MatchRunRunning).
+			Update("cancel_requested", true)
+		if res.Error != nil {
+			return nil, res.Error
+		}
+	}
+
+	run, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if res.RowsAffected == 0 {
+		return run, ErrMatchRunFinished
+	}
+	return run, nil
+}
+
+// Work claims and executes queued runs until ctx is cancelled.
+func (s *MatchRunService) Work(ctx context.Context, workerID string) {
+	log.Printf("Match worker %s started", workerID)
+	for ctx.Err() == nil {
+		run, err := s.claim(workerID)
+		if err != nil {
+			log.Printf("Match worker %s failed to claim a run: %v", workerID, err)
+		}
+		if run != nil {
+			s.execute(ctx, run)
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(matchRunPollInterval):
+		}
+	}
+	log.Printf("Match worker %s stopped", workerID)
+}
+
+// claim takes the oldest queued run. Runs abandoned by a dead worker are
+// requeued first.
+func (s *MatchRunService) claim(workerID string) (*models.MatchRun, error) {
+	stale := time.Now().Add(-matchRunStaleAfter)
+	if err := s.db.Model(&models.MatchRun{}).
+		Where("status = ? AND heartbeat_at < ? AND cancel_requested = ?", models.MatchRunRunning, stale, true).
+		Updates(map[string]interface{}{"status": models.MatchRunCancelled, "finished_at": time.Now()}).Error; err != nil {
+		return nil, err
+	}
+	if err := s.db.Model(&models.MatchRun{}).
+		Where("status = ? AND heartbeat_at < ?", models.MatchRunRunning, stale).
+		Updates(map[string]interface{}{"status": models.MatchRunQueued, "worker_id": ""}).Error; err != nil {
+		return nil, err
+	}
+
+	var run models.MatchRun
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ?", models.MatchRunQueued).
+			Order("created_at").
+			First(&run).Error
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		run.Status = models.MatchRunRunning
+		run.WorkerID = workerID
+		run.StartedAt = &now
+		run.HeartbeatAt = &now
+		return tx.Model(&run).Updates(map[string]interface{}{
+			"status":       run.Status,
+			"worker_id":    run.WorkerID,
+			"started_at":   now,
+			"heartbeat_at": now,
+		}).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+func (s *MatchRunService) execute(ctx context.Context, run *models.MatchRun) {
+	// Progress is only written after each batch, and a batch of slow AI
+	// calls can take longer than matchRunStaleAfter.
+	stop := make(chan struct{})
+	defer close(stop)
+	go s.heartbeat(run.ID, stop)
+
+	// The job may have been closed or deleted while the run was queued.
+	var job models.JobDescription
+	if err := s.db.Where("id = ?", run.JobID).First(&job).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = errMatchRunJobGone
+		}
+		s.finish(run, nil, err)
+		return
+	}
+	if !JobMatchable(&job) {
+		s.finish(run, nil, ErrJobNotMatchable)
+		return
+	}
+	if err := s.profiles.Resolve(&job); err != nil {
+		s.finish(run, nil, err)
+		return
+	}
+	// Score only the resumes of the job's organization. An unscoped run
+	// would score every organization's resumes.
+	if job.OrganizationID == nil {
+		s.finish(run, nil, errMatchRunUnscoped)
+		return
+	}
+	ctx = database.WithOrganization(ctx, *job.OrganizationID)
+
+	bulk := NewBulkMatchService(s.db, s.matcher, s.concurrency, s.batchSize)
+	total, err := bulk.Count(ctx, &job, run.Mode, run.Full)
+	if err != nil {
+		s.finish(run, nil, err)
+		return
+	}
+	if err := s.db.Model(run).Update("total", total).Error; err != nil {
+		s.finish(run, nil, err)
+		return
+	}
+
+	bulk.OnProgress(func(result *BulkMatchResult) error {
+		return s.progress(run, result)
+	})
+
+	result, err := bulk.Run(ctx, &job, run.Mode, run.Full)
+	s.finish(run, result, err)
+}
+
+// heartbeat renews the run's heartbeat_at until stop is closed.
+func (s *MatchRunService) heartbeat(id string, stop <-chan struct{}) {
+	ticker := time.NewTicker(matchRunHeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			err := s.db.Model(&models.MatchRun{}).
+				Where("id = ? AND status = ?", id, models.MatchRunRunning).
+				Update("heartbeat_at", now).Error
+			if err != nil {
+				log.Printf("Failed to renew heartbeat for match run %s: %v", id, err)
+			}
+		}
+	}
+}
+
+// progress stores the counters and reports whether the run was cancelled
+// or its job can no longer be matched.
+func (s *MatchRunService) progress(run *models.MatchRun, result *BulkMatchResult) error {
+	applyMatchResult(run, result)
+	if err := s.db.Model(run).Select(matchRunProgressColumns).Updates(run).Error; err != nil {
+		log.Printf("Failed to record progress for match run %s: %v", run.ID, err)
+	}
+
+	if s.cancelRequested(run.ID) {
+		return errMatchRunCancelled
+	}
+	return s.jobStillMatchable(run.JobID)
+}
+
+// jobStillMatchable returns why the job can no longer be matched, or nil.
+// A failed lookup is not held against the run.
+func (s *MatchRunService) jobStillMatchable(jobID string) error {
+	var job models.JobDescription
+	err := s.db.Select("id", "status").Where("id = ?", jobID).First(&job).Error
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return errMatchRunJobGone
+	case err != nil:
+		log.Printf("Failed to check job %s of a match run: %v", jobID, err)
+		return nil
+	case !JobMatchable(&job):
+		return ErrJobNotMatchable
+	}
+	return nil
+}
+
+func (s *MatchRunService) finish(run *models.MatchRun, result *BulkMatchResult, runErr error) {
+	if result != nil {
+		applyMatchResult(run, result)
+	}
+	now := time.Now()
+	run.FinishedAt = &now
+
+	switch {
+	case runErr == nil:
+		run.Status = models.MatchRunCompleted
+	case errors.Is(runErr, errMatchRunCancelled) || errors.Is(runErr, context.Canceled):
+		run.Status = models.MatchRunCancelled
+		// A worker shutting down cancels its context too; put the run back
+		// in the queue unless a user asked for the cancellation.
+		if !s.cancelRequested(run.ID) {
+			run.Status = models.MatchRunQueued
+			run.WorkerID = ""
+			run.FinishedAt = nil
+		}
+	default:
+		run.Status = models.MatchRunFailed
+		run.Error = runErr.Error()
+	}
+
+	columns := append([]string{"status", "error", "worker_id", "finished_at"}, matchRunProgressColumns...)
+	if err := s.db.Model(run).Select(columns).Updates(run).Error; err != nil {
+		log.Printf("Failed to finish match run %s: %v", run.ID, err)
+	}
+
+	if run.Status == models.MatchRunCompleted && run.NotifyTopN > 0 {
+		if err := s.notify(run); err != nil {
+			log.Printf("Failed to send notification for match run %s: %v", run.ID, err)
+		}
+	}
+}
+
+// notify sends the run's recipient the job's current top candidates.
+func (s *MatchRunService) notify(run *models.MatchRun) error {
+	var job models.JobDescription
+	if err := s.db.Select("id", "title").Where("id = ?", run.JobID).First(&job).Error; err != nil {
+		return err
+	}
+
+	var scores []models.CandidateScore
+	err := s.db.Where("job_id = ? AND disqualified = ?", run.JobID, false).
+		Preload("Resume", func(db *gorm.DB) *gorm.DB { return db.Select("id", "candidate_name", "email") }).
+		Order("score DESC").
+		Limit(run.NotifyTopN).
+		Find(&scores).Error
+	if err != nil {
+		return err
+	}
+
+	candidates := make([]models.NotifiedCandidate, 0, len(scores))
+	for _, score := range scores {
+		candidates = append(candidates, models.NotifiedCandidate{
+			ResumeID:      score.ResumeID,
+			CandidateName: score.Resume.CandidateName,
+			Email:         score.Resume.Email,
+			Score:         score.Score,
+		})
+	}
+
+	message := fmt.Sprintf("No existing candidates are eligible for %s yet.", job.Title)
+	if len(candidates) > 0 {
+		message = fmt.Sprintf("%d existing candidates match %s. The best scored %d.", len(candidates), job.Title, candidates[0].Score)
+	}
+
+	return s.db.Create(&models.Notification{
+		UserID:     run.NotifyUserID,
+		Type:       models.NotificationAutoMatch,
+		JobID:      job.ID,
+		MatchRunID: run.ID,
+		Title:      fmt.Sprintf("Top candidates for %s", job.Title),
+		Message:    message,
+		Candidates: candidates,
+	}).Error
+}
+
+func (s *MatchRunService) cancelRequested(id string) bool {
+	var run models.MatchRun
+	if err := s.db.Select("cancel_requested").Where("id = ?", id).First(&run).Error; err != nil {
+		return false
+	}
+	return run.CancelRequested
+}
+
+var matchRunProgressColumns = []string{"scored", "disqualified", "ai_fallbacks", "failed", "failures", "heartbeat_at"}
+
+func applyMatchResult(run *models.MatchRun, result *BulkMatchResult) {
+	now := time.Now()
+	run.Scored = result.Saved
+	run.Disqualified = result.Disqualified
+	run.AIFallbacks = result.AIFallbacks
+	run.Failed = len(result.Failures)
+	run.Failures = result.Failures
+	run.HeartbeatAt = &now
+}
@@ -0,0 +1,52 @@
+package services
+
+import (
+	"context"
+	"strings"
+)
+
+// OllamaProvider sends prompts to a local Ollama server, so resumes never
+// leave the host.
+type OllamaProvider struct {
+	baseURL string
+}
+
+type ollamaGenerateRequest struct {
+	Model  string `json:"model"`
+	Prompt string `json:"prompt"`
+	Stream bool   `json:"stream"`
+	Format string `json:"format,omitempty"`
+}
+
+type ollamaGenerateResponse struct {
+	Response string `json:"response"`
+}
+
+func NewOllamaProvider(baseURL string) *OllamaProvider {
+	return &OllamaProvider{baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+func (o *OllamaProvider) Name() string {
+	return "ollama"
+}
+
+func (o *OllamaProvider) Generate(ctx context.Context, req LLMRequest) (string, error) {
+	body := ollamaGenerateRequest{
+		Model:  req.Model,
+		Prompt: req.Prompt,
+	}
+	if req.JSON {
+		body.Format = "json"
+	}
+
+	var resp ollamaGenerateResponse
+	if err := postJSON(ctx, o.Name(), o.baseURL+"/api/generate", nil, body, &resp); err != nil {
+		return "", err
+	}
+
+	return resp.Response, nil
+}
+
+func (o *OllamaProvider) Close() error {
+	return nil
+}
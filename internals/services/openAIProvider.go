@@ -0,0 +1,116 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// OpenAIProvider talks to any endpoint implementing the OpenAI chat
+// completions API (OpenAI, Azure OpenAI proxies, vLLM, LM Studio, ...).
+type OpenAIProvider struct {
+	baseURL string
+	apiKey  string
+}
+
+type openAIChatRequest struct {
+	Model          string              `json:"model"`
+	Messages       []openAIChatMessage `json:"messages"`
+	Temperature    float64             `json:"temperature"`
+	ResponseFormat *openAIFormat       `json:"response_format,omitempty"`
+}
+
+type openAIChatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type openAIFormat struct {
+	Type string `json:"type"`
+}
+
+type openAIChatResponse struct {
+	Choices []struct {
+		Message openAIChatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+func NewOpenAIProvider(baseURL, apiKey string) *OpenAIProvider {
+	return &OpenAIProvider{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+	}
+}
+
+func (o *OpenAIProvider) Name() string {
+	return "openai"
+}
+
+func (o *OpenAIProvider) Generate(ctx context.Context, req LLMRequest) (string, error) {
+	body := openAIChatRequest{
+		Model:       req.Model,
+		Messages:    []openAIChatMessage{{Role: "user", Content: req.Prompt}},
+		Temperature: 0.2,
+	}
+	if req.JSON {
+		body.ResponseFormat = &openAIFormat{Type: "json_object"}
+	}
+
+	var resp openAIChatResponse
+	headers := map[string]string{}
+	if o.apiKey != "" {
+		headers["Authorization"] = "Bearer " + o.apiKey
+	}
+	if err := postJSON(ctx, o.Name(), o.baseURL+"/chat/completions", headers, body, &resp); err != nil {
+		return "", err
+	}
+
+	if len(resp.Choices) == 0 {
+		return "", fmt.Errorf("no response from AI")
+	}
+
+	return resp.Choices[0].Message.Content, nil
+}
+
+func (o *OpenAIProvider) Close() error {
+	return nil
+}
+
+// postJSON sends body as JSON and decodes the JSON reply into out. Non-2xx
+// replies are returned as a *ProviderError.
+func postJSON(ctx context.Context, provider, url string, headers map[string]string, body, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := llmHTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &ProviderError{Provider: provider, StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(respBody))}
+	}
+
+	return json.Unmarshal(respBody, out)
+}
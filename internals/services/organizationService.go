@@ -0,0 +1,483 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/database"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// AIProviderNone in an organization's settings turns AI scoring off.
+	AIProviderNone = "none"
+
+	organizationInviteTTL      = 7 * 24 * time.Hour
+	organizationSettingsMaxAge = time.Minute
+)
+
+var (
+	ErrOrganizationNotFound  = errors.New("organization not found")
+	ErrNotOrganizationMember = errors.New("not a member of the organization")
+	ErrLastOrganizationAdmin = errors.New("an organization needs at least one admin")
+	ErrInvalidOrganization   = errors.New("invalid organization")
+	ErrInviteNotFound        = errors.New("invite not found")
+	ErrInviteInvalid         = errors.New("invite is expired, used or for another email")
+	ErrAlreadyMember         = errors.New("user is already a member")
+)
+
+// OrganizationService manages organizations, their members, invites and
+// settings.
+type OrganizationService struct {
+	db *gorm.DB
+
+	mu       sync.Mutex
+	settings map[string]cachedOrganizationSettings
+}
+
+type cachedOrganizationSettings struct {
+	settings models.OrganizationSettings
+	loadedAt time.Time
+}
+
+func NewOrganizationService(db *gorm.DB) *OrganizationService {
+	return &OrganizationService{
+		db:       db,
+		settings: make(map[string]cachedOrganizationSettings),
+	}
+}
+
+// Create adds an organization with the user as its admin.
+func (s *OrganizationService) Create(name, userID string) (*models.Organization, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidOrganization)
+	}
+
+	organization := &models.Organization{Name: name}
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(organization).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.OrganizationMember{
+			OrganizationID: organization.ID,
+			UserID:         userID,
+			Role:           models.RoleAdmin,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return organization, nil
+}
+
+// OrganizationMembership is an organization together with the user's role in it.
+type OrganizationMembership struct {
+	Organization models.Organization `json:"organization"`
+	Role         string              `json:"role"`
+}
+
+// ListForUser returns the organizations the user belongs to, oldest
+// membership first.
+func (s *OrganizationService) ListForUser(userID string) ([]OrganizationMembership, error) {
+	var members []models.OrganizationMember
+	if err := s.db.Where("user_id = ?", userID).Order("created_at").Find(&members).Error; err != nil {
+		return nil, err
+	}
+
+	memberships := make([]OrganizationMembership, 0, len(members))
+	for _, member := range members {
+		organization, err := s.Get(member.OrganizationID)
+		if err != nil {
+			return nil, err
+		}
+		memberships = append(memberships, OrganizationMembership{Organization: *organization, Role: member.Role})
+	}
+	return memberships, nil
+}
+
+func (s *OrganizationService) Get(id string) (*models.Organization, error) {
+	var organization models.Organization
+	if err := s.db.Where("id = ?", id).First(&organization).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+	return &organization, nil
+}
+
+// Membership returns the user's membership of the organization.
+func (s *OrganizationService) Membership(organizationID, userID string) (*models.OrganizationMember, error) {
+	var member models.OrganizationMember
+	err := s.db.Where("organization_id = ? AND user_id = ?", organizationID, userID).First(&member).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotOrganizationMember
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// DefaultMembership returns the user's oldest membership, or nil if the
+// user belongs to no organization.
+func (s *OrganizationService) DefaultMembership(userID string) (*models.OrganizationMember, error) {
+	var member models.OrganizationMember
+	err := s.db.Where("user_id = ?", userID).Order("created_at").First(&member).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// UpdateSettings validates and stores an organization's settings.
+func (s *OrganizationService) UpdateSettings(id string, settings models.OrganizationSettings) (*models.Organization, error) {
+	organization, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateSettings(id, &settings); err != nil {
+		return nil, err
+	}
+
+	organization.Settings = settings
+	if err := s.db.Model(organization).Select("settings").Updates(organization).Error; err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	delete(s.settings, id)
+	s.mu.Unlock()
+	return organization, nil
+}
+
+// validateSettings checks new settings for the organization. The default
+// scoring profile must be one of its own profiles or a global one.
+func (s *OrganizationService) validateSettings(organizationID string, settings *models.OrganizationSettings) error {
+	if id := settings.DefaultScoringProfileID; id != nil {
+		scoped := s.db.WithContext(database.WithOrganization(context.Background(), organizationID))
+		if *id == "" {
+			settings.DefaultScoringProfileID = nil
+		} else if err := scoped.Where("id = ?", *id).First(&models.ScoringProfile{}).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w: default scoring profile %s does not exist", ErrInvalidOrganization, *id)
+			}
+			return err
+		}
+	}
+
+	settings.AIProvider = strings.ToLower(strings.TrimSpace(settings.AIProvider))
+	switch settings.AIProvider {
+	case "", AIProviderNone, "gemini", "openai", "ollama":
+	default:
+		return fmt.Errorf("%w: ai_provider must be empty, none, gemini, openai or ollama", ErrInvalidOrganization)
+	}
+	if settings.AIProvider == "" || settings.AIProvider == AIProviderNone {
+		settings.AIModel = ""
+	}
+
+	if settings.RetentionDays < 0 {
+		return fmt.Errorf("%w: retention_days cannot be negative", ErrInvalidOrganization)
+	}
+	return nil
+}
+
+// Settings returns an organization's settings. They are cached briefly,
+// since scoring reads them for every resume.
+func (s *OrganizationService) Settings(id string) (models.OrganizationSettings, error) {
+	s.mu.Lock()
+	cached, ok := s.settings[id]
+	s.mu.Unlock()
+	if ok && time.Since(cached.loadedAt) < organizationSettingsMaxAge {
+		return cached.settings, nil
+	}
+
+	organization, err := s.Get(id)
+	if err != nil {
+		return models.OrganizationSettings{}, err
+	}
+
+	s.mu.Lock()
+	s.settings[id] = cachedOrganizationSettings{settings: organization.Settings, loadedAt: time.Now()}
+	s.mu.Unlock()
+	return organization.Settings, nil
+}
+
+// OrganizationMemberInfo is a member with the user's name and email.
+type OrganizationMemberInfo struct {
+	UserID   string    `json:"user_id"`
+	Name     string    `json:"name"`
+	Email    string    `json:"email"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+func (s *OrganizationService) Members(organizationID string) ([]OrganizationMemberInfo, error) {
+	var members []OrganizationMemberInfo
+	err := s.db.Model(&models.OrganizationMember{}).
+		Select("organization_members.user_id, users.name, users.email, organization_members.role, organization_members.created_at AS joined_at").
+		Joins("JOIN users ON users.id = organization_members.user_id").
+		Where("organization_members.organization_id = ?", organizationID).
+		Order("users.email").
+		Scan(&members).Error
+	return members, err
+}
+
+// SetMemberRole changes a member's role and ends their session, so their
+// next token carries the new role.
+func (s *OrganizationService) SetMemberRole(organizationID, userID, role string) (*models.OrganizationMember, error) {
+	if err := ValidateRole(role); err != nil {
+		return nil, err
+	}
+
+	var member *models.OrganizationMember
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		member, err = lockMembership(tx, organizationID, userID)
+		if err != nil {
+			return err
+		}
+		if member.Role == models.RoleAdmin && role != models.RoleAdmin {
+			if err := ensureAnotherAdmin(tx, organizationID, userID); err != nil {
+				return err
+			}
+		}
+
+		member.Role = role
+		if err := tx.Model(member).Update("role", role).Error; err != nil {
+			return err
+		}
+		return EndSessions(tx, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// RemoveMember takes a user out of the organization and ends their session.
+func (s *OrganizationService) RemoveMember(organizationID, userID string) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		member, err := lockMembership(tx, organizationID, userID)
+		if err != nil {
+			return err
+		}
+		if member.Role == models.RoleAdmin {
+			if err := ensureAnotherAdmin(tx, organizationID, userID); err != nil {
+				return err
+			}
+		}
+		if err := tx.Delete(member).Error; err != nil {
+			return err
+		}
+		return EndSessions(tx, userID)
+	})
+}
+
+func lockMembership(tx *gorm.DB, organizationID, userID string) (*models.OrganizationMember, error) {
+	// Lock the organization's admins so two requests can't each demote a
+	// different last-but-one admin.
+	var admins []models.OrganizationMember
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("organization_id = ? AND role = ?", organizationID, models.RoleAdmin).
+		Find(&admins).Error; err != nil {
+		return nil, err
+	}
+
+	var member models.OrganizationMember
+	err := tx.Where("organization_id = ? AND user_id = ?", organizationID, userID).First(&member).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotOrganizationMember
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+func ensureAnotherAdmin(tx *gorm.DB, organizationID, userID string) error {
+	var admins int64
+	err := tx.Model(&models.OrganizationMember{}).
+		Where("organization_id = ? AND role = ? AND user_id <> ?", organizationID, models.RoleAdmin, userID).
+		Count(&admins).Error
+	if err != nil {
+		return err
+	}
+	if admins == 0 {
+		return ErrLastOrganizationAdmin
+	}
+	return nil
+}
+
+// CreateInvite invites an email address to the organization. The returned
+// token is shown once and is needed to accept the invite.
+func (s *OrganizationService) CreateInvite(organizationID, email, role, invitedBy string) (*models.OrganizationInvite, string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return nil, "", fmt.Errorf("%w: email is required", ErrInvalidOrganization)
+	}
+	if err := ValidateRole(role); err != nil {
+		return nil, "", err
+	}
+
+	var existing int64
+	err := s.db.Model(&models.OrganizationMember{}).
+		Joins("JOIN users ON users.id = organization_members.user_id").
+		Where("organization_members.organization_id = ? AND LOWER(users.email) = ?", organizationID, email).
+		Count(&existing).Error
+	if err != nil {
+		return nil, "", err
+	}
+	if existing > 0 {
+		return nil, "", ErrAlreadyMember
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", err
+	}
+	token := hex.EncodeToString(secret)
+
+	invite := &models.OrganizationInvite{
+		OrganizationID: organizationID,
+		Email:          email,
+		Role:           role,
+		TokenHash:      hashToken(token),
+		ExpiresAt:      time.Now().Add(organizationInviteTTL),
+	}
+	if invitedBy != "" {
+		invite.InvitedBy = &invitedBy
+	}
+	if err := s.db.Create(invite).Error; err != nil {
+		return nil, "", err
+	}
+	return invite, token, nil
+}
+
+// PendingInvites lists the organization's invites that can still be
+// accepted.
+func (s *OrganizationService) PendingInvites(organizationID string) ([]models.OrganizationInvite, error) {
+	var invites []models.OrganizationInvite
+	err := s.db.Where("organization_id = ? AND accepted_at IS NULL AND expires_at > ?", organizationID, time.Now()).
+		Order("created_at DESC").
+		Find(&invites).Error
+	return invites, err
+}
+
+func (s *OrganizationService) RevokeInvite(organizationID, inviteID string) error {
+	result := s.db.Where("id = ? AND organization_id = ? AND accepted_at IS NULL", inviteID, organizationID).
+		Delete(&models.OrganizationInvite{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrInviteNotFound
+	}
+	return nil
+}
+
+// AcceptInvite adds the user to the invite's organization. The invite must
+// be addressed to the user's email.
+func (s *OrganizationService) AcceptInvite(token string, user *models.User) (*models.OrganizationMember, error) {
+	var member *models.OrganizationMember
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var invite models.OrganizationInvite
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("token_hash = ?", hashToken(strings.TrimSpace(token))).
+			First(&invite).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInviteNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if invite.AcceptedAt != nil || time.Now().After(invite.ExpiresAt) || !strings.EqualFold(invite.Email, user.Email) {
+			return ErrInviteInvalid
+		}
+
+		var existing int64
+		if err := tx.Model(&models.OrganizationMember{}).
+			Where("organization_id = ? AND user_id = ?", invite.OrganizationID, user.ID).
+			Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			return ErrAlreadyMember
+		}
+
+		member = &models.OrganizationMember{OrganizationID: invite.OrganizationID, UserID: user.ID, Role: invite.Role}
+		if err := tx.Create(member).Error; err != nil {
+			return err
+		}
+		return tx.Model(&invite).Update("accepted_at", time.Now()).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// RunRetention deletes resumes past their organization's retention period
+// every interval until ctx is cancelled.
+func (s *OrganizationService) RunRetention(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.purgeExpiredResumes(ctx); err != nil {
+			log.Printf("Resume retention failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *OrganizationService) purgeExpiredResumes(ctx context.Context) error {
+	var organizations []models.Organization
+	if err := s.db.WithContext(ctx).Find(&organizations).Error; err != nil {
+		return err
+	}
+
+	for _, organization := range organizations {
+		days := organization.Settings.RetentionDays
+		if days <= 0 {
+			continue
+		}
+
+		cutoff := time.Now().AddDate(0, 0, -days)
+		var ids []string
+		err := s.db.WithContext(ctx).Model(&models.Resume{}).
+			Where("organization_id = ? AND created_at < ?", organization.ID, cutoff).
+			Pluck("id", &ids).Error
+		if err != nil {
+			return err
+		}
+
+		for _, id := range ids {
+			if err := DeleteResume(s.db.WithContext(ctx), id); err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				log.Printf("Failed to delete expired resume %s: %v", id, err)
+			}
+		}
+		if len(ids) > 0 {
+			log.Printf("Deleted %d resumes past the %d day retention of organization %s", len(ids), days, organization.ID)
+		}
+	}
+	return nil
+}
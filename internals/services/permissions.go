@@ -0,0 +1,96 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+)
+
+// Permission is an action a route requires.
+type Permission string
+
+const (
+	PermJobsRead             Permission = "jobs:read"
+	PermJobsWrite            Permission = "jobs:write"
+	PermJobsMatch            Permission = "jobs:match" // Queue and cancel match runs
+	PermCandidatesRead       Permission = "candidates:read"
+	PermResumesRead          Permission = "resumes:read"
+	PermResumesWrite         Permission = "resumes:write"
+	PermScoringProfilesRead  Permission = "scoring_profiles:read"
+	PermScoringProfilesWrite Permission = "scoring_profiles:write"
+	PermOrganizationManage   Permission = "organization:manage" // Settings, members and invites
+
+	// Platform permissions cover data shared by every organization. They
+	// are granted by the user's own role, not the organization role.
+	PermSkillsManage        Permission = "skills:manage"
+	PermUsersManage         Permission = "users:manage"
+	PermSystemManage        Permission = "system:manage" // AI cache and status, audit log
+	PermOrganizationsCreate Permission = "organizations:create"
+)
+
+var platformPermissions = []Permission{PermSkillsManage, PermUsersManage, PermSystemManage, PermOrganizationsCreate}
+
+var ErrInvalidRole = errors.New("invalid role")
+
+// rolePermissions is the permission matrix for organization roles. Admins
+// may do everything.
+var rolePermissions = map[string][]Permission{
+	models.RoleRecruiter: {
+		PermJobsRead, PermJobsWrite, PermJobsMatch,
+		PermCandidatesRead,
+		PermResumesRead, PermResumesWrite,
+		PermScoringProfilesRead, PermScoringProfilesWrite,
+	},
+	models.RoleHiringManager: {
+		PermJobsRead,
+		PermCandidatesRead,
+		PermResumesRead,
+		PermScoringProfilesRead,
+	},
+	models.RoleViewer: {
+		PermJobsRead,
+		PermCandidatesRead,
+	},
+}
+
+// Roles lists the roles, most privileged first.
+var Roles = []string{models.RoleAdmin, models.RoleRecruiter, models.RoleHiringManager, models.RoleViewer}
+
+// ValidateRole checks that role is one of Roles.
+func ValidateRole(role string) error {
+	if !slices.Contains(Roles, role) {
+		return fmt.Errorf("%w %q", ErrInvalidRole, role)
+	}
+	return nil
+}
+
+// PlatformRoles lists the roles a user may hold outside organizations.
+var PlatformRoles = []string{models.RoleAdmin, models.RolePending}
+
+// ValidatePlatformRole checks that role is one of PlatformRoles.
+func ValidatePlatformRole(role string) error {
+	if !slices.Contains(PlatformRoles, role) {
+		return fmt.Errorf("%w %q", ErrInvalidRole, role)
+	}
+	return nil
+}
+
+// IsPlatformPermission reports whether the permission is checked against
+// the user's platform role rather than their organization role.
+func IsPlatformPermission(permission Permission) bool {
+	return slices.Contains(platformPermissions, permission)
+}
+
+// HasPermission reports whether the role grants the permission. Platform
+// permissions are only granted to admins.
+func HasPermission(role string, permission Permission) bool {
+	if role == models.RoleAdmin {
+		return true
+	}
+	if IsPlatformPermission(permission) {
+		return false
+	}
+	return slices.Contains(rolePermissions[role], permission)
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+)
+
+func TestHasPermission(t *testing.T) {
+	tests := []struct {
+		role       string
+		permission Permission
+		want       bool
+	}{
+		{models.RoleAdmin, PermSystemManage, true},
+		{models.RoleRecruiter, PermJobsWrite, true},
+		{models.RoleRecruiter, PermUsersManage, false},
+		{models.RoleHiringManager, PermResumesRead, true},
+		{models.RoleViewer, PermResumesRead, false},
+		{models.RolePending, PermJobsRead, false},
+		{models.RolePending, PermCandidatesRead, false},
+		{models.RoleAdmin, PermOrganizationsCreate, true},
+		{models.RoleRecruiter, PermOrganizationsCreate, false},
+		{models.RolePending, PermOrganizationsCreate, false},
+		{"", PermJobsRead, false},
+	}
+	for _, tt := range tests {
+		if got := HasPermission(tt.role, tt.permission); got != tt.want {
+			t.Errorf("HasPermission(%q, %q) = %v, want %v", tt.role, tt.permission, got, tt.want)
+		}
+	}
+}
+
+func TestPendingIsNotAssignable(t *testing.T) {
+	if err := ValidateRole(models.RolePending); err == nil {
+		t.Error("pending accepted as a role to grant")
+	}
+}
+
+func TestValidatePlatformRole(t *testing.T) {
+	for role, valid := range map[string]bool{
+		models.RoleAdmin:         true,
+		models.RolePending:       true,
+		models.RoleRecruiter:     false,
+		models.RoleHiringManager: false,
+		models.RoleViewer:        false,
+		"":                       false,
+	} {
+		if err := ValidatePlatformRole(role); (err == nil) != valid {
+			t.Errorf("ValidatePlatformRole(%q) = %v", role, err)
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/taxonomy"
 	"github.com/unidoc/unipdf/v3/extractor"
 	"github.com/unidoc/unipdf/v3/model"
 )
@@ -89,7 +90,7 @@ func (r *ResumeParserService) parsePDF(content []byte) (string, error) {
 }
 
 func (r *ResumeParserService) parseDOCX(content []byte) (string, error) {
-	return "", errors.New("DOCX parsing is not fully implemented yet. Please use PDF or TXT files for now")
+	return extractDOCXText(content)
 }
 
 func (r *ResumeParserService) extractResumeData(text string) *models.Resume {
@@ -105,19 +106,7 @@ func (r *ResumeParserService) extractResumeData(text string) *models.Resume {
 		resume.Phone = phone
 	}
 
-	skillKeywords := []string{"python", "java", "javascript", "go", "c++", "c#", "php", "ruby", "swift", "kotlin",
-		"react", "angular", "vue", "node", "django", "flask", "spring", "laravel", "express",
-		"mysql", "postgresql", "mongodb", "redis", "docker", "kubernetes", "aws", "azure", "gcp",
-		"git", "linux", "windows", "agile", "scrum", "ci/cd", "jenkins", "github", "gitlab"}
-
-	var skills []string
-	lowerText := strings.ToLower(text)
-	for _, skill := range skillKeywords {
-		if strings.Contains(lowerText, skill) {
-			skills = append(skills, strings.Title(skill))
-		}
-	}
-	resume.Skills = skills
+	resume.Skills = taxonomy.Default().Extract(text)
 
 	lines := strings.Split(text, "\n")
 	for _, line := range lines {
@@ -125,12 +114,19 @@ func (r *ResumeParserService) extractResumeData(text string) *models.Resume {
 		if line != "" && len(line) > 3 && len(line) < 50 {
 			nameRegex := regexp.MustCompile(`^[a-zA-Z\s.]{3,50}$`)
 			if nameRegex.MatchString(line) && !strings.Contains(strings.ToLower(line), "email") &&
-			   !strings.Contains(strings.ToLower(line), "phone") && !strings.Contains(line, "@") {
+				!strings.Contains(strings.ToLower(line), "phone") && !strings.Contains(line, "@") {
 				resume.CandidateName = line
 				break
 			}
 		}
 	}
 
+	sections := segmentResume(text)
+	location := parseHeaderLocation(sections[sectionHeader])
+	resume.Location = &location
+	resume.Experience = parseExperienceSection(sections[sectionExperience])
+	resume.Education = parseEducationSection(sections[sectionEducation])
+	resume.Certifications = parseCertificationsSection(sections[sectionCertifications])
+
 	return resume
 }
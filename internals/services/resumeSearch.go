@@ -0,0 +1,125 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/taxonomy"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultResumePageSize = 20
+	maxResumePageSize     = 100
+)
+
+var ErrInvalidResumeFilter = errors.New("invalid resume filter")
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// ResumeFilter narrows the resume list. Name and email match partially and
+// ignore case.
+type ResumeFilter struct {
+	Skills         []string   `form:"skills"`      // Skills the resume must list
+	SkillsMode     string     `form:"skills_mode"` // all (default) or any
+	Name           string     `form:"name"`
+	Email          string     `form:"email"`
+	UploadedAfter  *time.Time `form:"-"`
+	UploadedBefore *time.Time `form:"-"`
+	Cursor         string     `form:"cursor"`
+	Limit          int        `form:"limit"`
+}
+
+// ResumePage is one page of resumes, newest first. NextCursor is empty on
+// the last page.
+type ResumePage struct {
+	Resumes    []models.Resume `json:"resumes"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+}
+
+type resumeCursor struct {
+	CreatedAt time.Time `json:"c"`
+	ID        string    `json:"id"`
+}
+
+// SearchResumes returns a page of resumes matching the filter, using keyset
+// pagination on the upload time and ID. The parsed text is not loaded.
+func SearchResumes(db *gorm.DB, filter ResumeFilter) (*ResumePage, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultResumePageSize
+	}
+	if limit > maxResumePageSize {
+		limit = maxResumePageSize
+	}
+
+	query := db.Model(&models.Resume{})
+	if name := strings.TrimSpace(filter.Name); name != "" {
+		query = query.Where("candidate_name ILIKE ?", "%"+likeEscaper.Replace(name)+"%")
+	}
+	if email := strings.TrimSpace(filter.Email); email != "" {
+		query = query.Where("email ILIKE ?", "%"+likeEscaper.Replace(email)+"%")
+	}
+	if filter.UploadedAfter != nil {
+		query = query.Where("created_at > ?", *filter.UploadedAfter)
+	}
+	if filter.UploadedBefore != nil {
+		query = query.Where("created_at < ?", *filter.UploadedBefore)
+	}
+
+	if skills := taxonomy.Default().Normalize(filter.Skills); len(skills) > 0 {
+		switch strings.ToLower(filter.SkillsMode) {
+		case "", "all":
+			query = query.Where("skills @> ARRAY[?]::text[]", skills)
+		case "any":
+			query = query.Where("skills && ARRAY[?]::text[]", skills)
+		default:
+			return nil, fmt.Errorf("%w: skills_mode must be all or any", ErrInvalidResumeFilter)
+		}
+	}
+
+	if filter.Cursor != "" {
+		cursor, err := decodeResumeCursor(filter.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where("(created_at, id) < (?, ?)", cursor.CreatedAt, cursor.ID)
+	}
+
+	var resumes []models.Resume
+	err := query.
+		Select(resumeSummaryColumns).
+		Order("created_at DESC, id DESC").
+		Limit(limit + 1).
+		Find(&resumes).Error
+	if err != nil {
+		return nil, err
+	}
+
+	page := &ResumePage{Resumes: resumes}
+	if len(resumes) > limit {
+		page.Resumes = resumes[:limit]
+		last := resumes[limit-1]
+		data, _ := json.Marshal(resumeCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+		page.NextCursor = base64.RawURLEncoding.EncodeToString(data)
+	}
+	return page, nil
+}
+
+func decodeResumeCursor(encoded string) (*resumeCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidResumeFilter)
+	}
+
+	var cursor resumeCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == "" || cursor.CreatedAt.IsZero() {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidResumeFilter)
+	}
+	return &cursor, nil
+}
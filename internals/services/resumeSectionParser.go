@@ -0,0 +1,346 @@
+package services
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+)
+
+type resumeSection string
+
+const (
+	sectionHeader         resumeSection = "header"
+	sectionSummary        resumeSection = "summary"
+	sectionExperience     resumeSection = "experience"
+	sectionEducation      resumeSection = "education"
+	sectionCertifications resumeSection = "certifications"
+	sectionSkills         resumeSection = "skills"
+	sectionProjects       resumeSection = "projects"
+	sectionOther          resumeSection = "other"
+)
+
+// sectionHeadings maps normalized heading text to the section it opens.
+var sectionHeadings = map[string]resumeSection{
+	"summary":                     sectionSummary,
+	"professional summary":        sectionSummary,
+	"career summary":              sectionSummary,
+	"profile":                     sectionSummary,
+	"professional profile":        sectionSummary,
+	"about me":                    sectionSummary,
+	"objective":                   sectionSummary,
+	"career objective":            sectionSummary,
+	"experience":                  sectionExperience,
+	"work experience":             sectionExperience,
+	"professional experience":     sectionExperience,
+	"relevant experience":         sectionExperience,
+	"employment":                  sectionExperience,
+	"employment history":          sectionExperience,
+	"work history":                sectionExperience,
+	"career history":              sectionExperience,
+	"professional background":     sectionExperience,
+	"education":                   sectionEducation,
+	"academic background":         sectionEducation,
+	"academic qualifications":     sectionEducation,
+	"educational qualifications":  sectionEducation,
+	"education and training":      sectionEducation,
+	"academics":                   sectionEducation,
+	"certifications":              sectionCertifications,
+	"certification":               sectionCertifications,
+	"certificates":                sectionCertifications,
+	"licenses and certifications": sectionCertifications,
+	"certifications and licenses": sectionCertifications,
+	"professional certifications": sectionCertifications,
+	"skills":                      sectionSkills,
+	"technical skills":            sectionSkills,
+	"key skills":                  sectionSkills,
+	"core competencies":           sectionSkills,
+	"technologies":                sectionSkills,
+	"skills and technologies":     sectionSkills,
+	"projects":                    sectionProjects,
+	"personal projects":           sectionProjects,
+	"key projects":                sectionProjects,
+	"awards":                      sectionOther,
+	"achievements":                sectionOther,
+	"publications":                sectionOther,
+	"languages":                   sectionOther,
+	"interests":                   sectionOther,
+	"hobbies":                     sectionOther,
+	"references":                  sectionOther,
+	"volunteer experience":        sectionOther,
+	"volunteering":                sectionOther,
+}
+
+var (
+	bulletPrefix = regexp.MustCompile(`^\s*(?:[-*•▪◦●‣–—]|\d+[.)])\s+`)
+	yearPattern  = regexp.MustCompile(`\b(?:19|20)\d{2}\b`)
+
+	headerSeparators = regexp.MustCompile(`\s+(?:\||-|–|—|@|at)\s+|\s*[|,\t]\s*`)
+
+	roleKeywords = regexp.MustCompile(`(?i)\b(?:engineer|developer|programmer|manager|intern|analyst|lead|architect|consultant|designer|scientist|director|specialist|administrator|officer|head|vp|president|associate|coordinator|assistant|technician|researcher|founder|cto|ceo|devops|sre|tester|qa)\b`)
+
+	locationLabel     = regexp.MustCompile(`(?i)^(?:location|address|based in|city)\s*[:\-–]\s*(.+)$`)
+	locationParts     = regexp.MustCompile(`\s*[|•·\t]\s*`)
+	cityRegionPattern = regexp.MustCompile(`^[\p{L} .'-]{2,40},\s*[\p{L} .'-]{2,40}(?:,\s*[\p{L} .'-]{2,40})?$`)
+	contactPattern    = regexp.MustCompile(`(?i)@|https?://|www\.|linkedin|github|\d{3}`)
+	remoteWord        = regexp.MustCompile(`(?i)\bremote\b`)
+
+	degreeKeywords      = regexp.MustCompile(`(?i)(?:\b(?:bachelor|master|doctor|doctorate|phd|ph\.d|mba|bsc|msc|b\.?sc|m\.?sc|b\.?tech|m\.?tech|b\.?e|m\.?e|b\.?a|m\.?a|b\.?s|m\.?s|b\.?com|m\.?com|bca|mca|associate|diploma|high school|secondary|ged)\b)`)
+	institutionKeywords = regexp.MustCompile(`(?i)\b(?:university|college|institute|school|academy|polytechnic|iit|nit|mit)\b`)
+)
+
+// segmentResume splits resume text into sections keyed by the heading that
+// precedes them. Lines before the first recognised heading belong to the
+// header section. Blank lines are kept so entries can be split on them.
+func segmentResume(text string) map[resumeSection][]string {
+	sections := make(map[resumeSection][]string)
+	current := sectionHeader
+
+	for _, rawLine := range strings.Split(text, "\n") {
+		line := strings.TrimSpace(rawLine)
+		if section, ok := detectSectionHeading(line); ok {
+			current = section
+			continue
+		}
+		sections[current] = append(sections[current], line)
+	}
+
+	return sections
+}
+
+// ParseResumeLocation returns the candidate's location from the contact
+// block of resume text, or "" when there is none.
+func ParseResumeLocation(text string) string {
+	return parseHeaderLocation(segmentResume(text)[sectionHeader])
+}
+
+// parseHeaderLocation finds the candidate's location in the contact block at
+// the top of the resume: a labelled line such as "Location: Berlin", or
+// else a contact part written as "City, Region" or mentioning remote work.
+// It returns "" when the block has no recognisable location.
+func parseHeaderLocation(lines []string) string {
+	for _, line := range lines {
+		if match := locationLabel.FindStringSubmatch(line); match != nil {
+			return strings.TrimSpace(match[1])
+		}
+	}
+
+	for _, line := range lines {
+		for _, part := range locationParts.Split(line, -1) {
+			part = strings.TrimSpace(part)
+			if part == "" || contactPattern.MatchString(part) {
+				continue
+			}
+			if cityRegionPattern.MatchString(part) || (remoteWord.MatchString(part) && len(part) <= 40) {
+				return part
+			}
+		}
+	}
+	return ""
+}
+
+func detectSectionHeading(line string) (resumeSection, bool) {
+	if line == "" || len(line) > 40 {
+		return "", false
+	}
+
+	normalized := strings.ToLower(line)
+	normalized = bulletPrefix.ReplaceAllString(normalized, "")
+	normalized = strings.TrimRight(normalized, ":-–— ")
+	normalized = strings.ReplaceAll(normalized, "&", "and")
+	normalized = strings.Join(strings.Fields(normalized), " ")
+
+	section, ok := sectionHeadings[normalized]
+	return section, ok
+}
+
+// splitSectionEntries groups section lines into entries. A new entry starts
+// after a blank line, when a non-bullet line follows bullet points, or when a
+// second date range shows up in the entry's heading lines.
+func splitSectionEntries(lines []string) [][]string {
+	var entries [][]string
+	var current []string
+	hasBullets := false
+	hasDates := false
+
+	flush := func() {
+		if len(current) > 0 {
+			entries = append(entries, current)
+		}
+		current = nil
+		hasBullets = false
+		hasDates = false
+	}
+
+	for _, line := range lines {
+		if line == "" {
+			flush()
+			continue
+		}
+
+		isBullet := bulletPrefix.MatchString(line)
+		hasRange := dateRangeRegex.MatchString(line)
+
+		if !isBullet && (hasBullets || (hasRange && hasDates)) {
+			flush()
+		}
+
+		current = append(current, line)
+		if isBullet {
+			hasBullets = true
+		}
+		if hasRange {
+			hasDates = true
+		}
+	}
+	flush()
+
+	return entries
+}
+
+// parseExperienceSection turns the lines of an experience section into
+// Experience rows. Heading lines give the role, company and dates; bullet
+// points and any remaining lines become the description.
+func parseExperienceSection(lines []string) []models.Experience {
+	var experiences []models.Experience
+
+	for _, entry := range splitSectionEntries(lines) {
+		var exp models.Experience
+		var headerParts, description []string
+
+		for _, line := range entry {
+			if bulletPrefix.MatchString(line) || len(description) > 0 || len(headerParts) >= 3 {
+				description = append(description, strings.TrimSpace(bulletPrefix.ReplaceAllString(line, "")))
+				continue
+			}
+
+			if exp.Duration == "" {
+				if dates := dateRangeRegex.FindString(line); dates != "" {
+					exp.Duration = dates
+					line = strings.TrimSpace(strings.Replace(line, dates, "", 1))
+				}
+			}
+
+			for _, part := range headerSeparators.Split(line, -1) {
+				part = strings.Trim(part, " ()-–—|,")
+				if part != "" {
+					headerParts = append(headerParts, part)
+				}
+			}
+		}
+
+		exp.Role, exp.Company = splitRoleAndCompany(headerParts)
+		exp.Description = strings.Join(description, "\n")
+
+		if exp.Role == "" && exp.Company == "" && exp.Duration == "" {
+			continue
+		}
+		experiences = append(experiences, exp)
+	}
+
+	return experiences
+}
+
+// splitRoleAndCompany picks the part that reads like a job title as the role
+// and the first remaining part as the company. Without a job-title keyword
+// the "Role, Company" ordering is assumed.
+func splitRoleAndCompany(parts []string) (string, string) {
+	if len(parts) == 0 {
+		return "", ""
+	}
+
+	roleIdx := -1
+	for i, part := range parts {
+		if roleKeywords.MatchString(part) {
+			roleIdx = i
+			break
+		}
+	}
+	if roleIdx == -1 {
+		roleIdx = 0
+	}
+
+	role := parts[roleIdx]
+	company := ""
+	for i, part := range parts {
+		if i != roleIdx {
+			company = part
+			break
+		}
+	}
+
+	return role, company
+}
+
+// parseEducationSection turns the lines of an education section into
+// Education rows. The graduation year is the latest year in the entry. A
+// second degree inside one entry starts a new row, since education lists are
+// often written one degree per line without blank lines in between.
+func parseEducationSection(lines []string) []models.Education {
+	var educations []models.Education
+
+	for _, entry := range splitSectionEntries(lines) {
+		var edu models.Education
+		var leftovers []string
+
+		flush := func() {
+			if edu.Degree == "" && len(leftovers) > 0 && edu.Institution != "" {
+				edu.Degree = leftovers[0]
+			} else if edu.Institution == "" && len(leftovers) > 0 && edu.Degree != "" {
+				edu.Institution = leftovers[0]
+			}
+			if edu.Degree != "" || edu.Institution != "" {
+				educations = append(educations, edu)
+			}
+			edu = models.Education{}
+			leftovers = nil
+		}
+
+		for _, line := range entry {
+			line = strings.TrimSpace(bulletPrefix.ReplaceAllString(line, ""))
+			if edu.Degree != "" && degreeKeywords.MatchString(line) {
+				flush()
+			}
+
+			for _, y := range yearPattern.FindAllString(line, -1) {
+				if year, err := strconv.Atoi(y); err == nil && year > edu.Year {
+					edu.Year = year
+				}
+			}
+			line = strings.TrimSpace(dateRangeRegex.ReplaceAllString(line, ""))
+			line = strings.TrimSpace(yearPattern.ReplaceAllString(line, ""))
+
+			for _, part := range headerSeparators.Split(line, -1) {
+				part = strings.Trim(part, " ()-–—|,")
+				switch {
+				case part == "":
+				case edu.Degree == "" && degreeKeywords.MatchString(part):
+					edu.Degree = part
+				case edu.Institution == "" && institutionKeywords.MatchString(part):
+					edu.Institution = part
+				default:
+					leftovers = append(leftovers, part)
+				}
+			}
+		}
+		flush()
+	}
+
+	return educations
+}
+
+// parseCertificationsSection returns one certification per non-empty line.
+func parseCertificationsSection(lines []string) []string {
+	var certifications []string
+
+	for _, line := range lines {
+		line = strings.TrimSpace(bulletPrefix.ReplaceAllString(line, ""))
+		if line == "" {
+			continue
+		}
+		certifications = append(certifications, line)
+	}
+
+	return certifications
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+)
+
+const sampleResume = `Jane Doe
+jane@example.com | Berlin, Germany
+
+PROFESSIONAL SUMMARY
+Backend engineer who likes boring technology.
+
+Work Experience:
+Senior Software Engineer | Acme GmbH | Jan 2021 - Present
+- Built the billing service in Go
+- Cut p99 latency by 40%
+Backend Developer at Initech
+03/2018 - 12/2020
+• Maintained the PostgreSQL cluster
+
+Education & Training
+M.Sc. Computer Science, Technical University of Munich, 2017
+B.Tech Information Technology, IIT Delhi, 2012 - 2016
+
+Licenses and Certifications
+- AWS Certified Solutions Architect
+- CKA
+
+Skills
+Go, PostgreSQL, Kubernetes`
+
+func TestDetectSectionHeading(t *testing.T) {
+	tests := []struct {
+		line    string
+		section resumeSection
+		ok      bool
+	}{
+		{"EXPERIENCE", sectionExperience, true},
+		{"Work History:", sectionExperience, true},
+		{"  education and TRAINING ", sectionEducation, true},
+		{"Education & Training", sectionEducation, true},
+		{"- Technical Skills", sectionSkills, true},
+		{"Licenses and Certifications", sectionCertifications, true},
+		{"Experience with large Go codebases", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		section, ok := detectSectionHeading(tt.line)
+		if ok != tt.ok || (ok && section != tt.section) {
+			t.Errorf("detectSectionHeading(%q) = %q, %v; want %q, %v", tt.line, section, ok, tt.section, tt.ok)
+		}
+	}
+}
+
+func TestSegmentResume(t *testing.T) {
+	sections := segmentResume(sampleResume)
+
+	want := map[resumeSection]int{
+		sectionHeader:         3,
+		sectionSummary:        2,
+		sectionExperience:     7,
+		sectionEducation:      3,
+		sectionCertifications: 3,
+		sectionSkills:         1,
+	}
+	for section, lines := range want {
+		if got := len(sections[section]); got != lines {
+			t.Errorf("%s: %d lines %q, want %d", section, got, sections[section], lines)
+		}
+	}
+	if sections[sectionHeader][0] != "Jane Doe" {
+		t.Errorf("header starts with %q", sections[sectionHeader][0])
+	}
+}
+
+func TestParseExperienceSection(t *testing.T) {
+	experiences := parseExperienceSection(segmentResume(sampleResume)[sectionExperience])
+
+	want := []models.Experience{
+		{
+			Role:        "Senior Software Engineer",
+			Company:     "Acme GmbH",
+			Duration:    "Jan 2021 - Present",
+			Description: "Built the billing service in Go\nCut p99 latency by 40%",
+		},
+		{
+			Role:        "Backend Developer",
+			Company:     "Initech",
+			Duration:    "03/2018 - 12/2020",
+			Description: "Maintained the PostgreSQL cluster",
+		},
+	}
+	if len(experiences) != len(want) {
+		t.Fatalf("got %d experiences: %+v", len(experiences), experiences)
+	}
+	for i, exp := range experiences {
+		if exp.Role != want[i].Role || exp.Company != want[i].Company ||
+			exp.Duration != want[i].Duration || exp.Description != want[i].Description {
+			t.Errorf("experience %d = %+v, want %+v", i, exp, want[i])
+		}
+	}
+}
+
+func TestSplitSectionEntries(t *testing.T) {
+	lines := []string{
+		"Engineer, Acme, 2019 - 2021",
+		"Developer, Initech, 2016 - 2019",
+		"",
+		"Intern, Globex",
+		"- Wrote tests",
+		"Analyst, Umbrella",
+	}
+	entries := splitSectionEntries(lines)
+	if len(entries) != 4 {
+		t.Fatalf("got %d entries: %q", len(entries), entries)
+	}
+	if !slices.Equal(entries[2], []string{"Intern, Globex", "- Wrote tests"}) {
+		t.Errorf("entry 2 = %q", entries[2])
+	}
+}
+
+func TestParseEducationSection(t *testing.T) {
+	educations := parseEducationSection(segmentResume(sampleResume)[sectionEducation])
+
+	want := []models.Education{
+		{Degree: "M.Sc. Computer Science", Institution: "Technical University of Munich", Year: 2017},
+		{Degree: "B.Tech Information Technology", Institution: "IIT Delhi", Year: 2016},
+	}
+	if len(educations) != len(want) {
+		t.Fatalf("got %d educations: %+v", len(educations), educations)
+	}
+	for i, edu := range educations {
+		if edu.Degree != want[i].Degree || edu.Institution != want[i].Institution || edu.Year != want[i].Year {
+			t.Errorf("education %d = %+v, want %+v", i, edu, want[i])
+		}
+	}
+}
+
+func TestParseCertificationsSection(t *testing.T) {
+	got := parseCertificationsSection(segmentResume(sampleResume)[sectionCertifications])
+	want := []string{"AWS Certified Solutions Architect", "CKA"}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
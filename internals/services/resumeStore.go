@@ -0,0 +1,109 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"os"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+	"gorm.io/gorm"
+)
+
+// ResumeChanges are the edits to a resume. Columns lists the resume columns
+// to write from the resume itself; Education and Experience replace the
+// stored entries when set.
+type ResumeChanges struct {
+	Columns    []string
+	Education  *[]models.Education
+	Experience *[]models.Experience
+}
+
+// UpdateResume saves corrections to a parsed resume in one transaction.
+// Saving bumps updated_at, so the next match runs re-score the resume.
+func UpdateResume(db *gorm.DB, resume *models.Resume, changes ResumeChanges) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		// Always select a column so that updated_at is written even when
+		// only the education or experience changed.
+		columns := append([]string{"updated_at"}, changes.Columns...)
+		if err := tx.Model(resume).Select(columns).Updates(resume).Error; err != nil {
+			return err
+		}
+
+		if changes.Education != nil {
+			if err := tx.Where("resume_id = ?", resume.ID).Delete(&models.Education{}).Error; err != nil {
+				return err
+			}
+			education := *changes.Education
+			for i := range education {
+				education[i].ID = ""
+				education[i].ResumeID = resume.ID
+			}
+			if len(education) > 0 {
+				if err := tx.Create(&education).Error; err != nil {
+					return err
+				}
+			}
+			resume.Education = education
+		}
+
+		if changes.Experience != nil {
+			if err := tx.Where("resume_id = ?", resume.ID).Delete(&models.Experience{}).Error; err != nil {
+				return err
+			}
+			experience := *changes.Experience
+			for i := range experience {
+				experience[i].ID = ""
+				experience[i].ResumeID = resume.ID
+			}
+			if len(experience) > 0 {
+				if err := tx.Create(&experience).Error; err != nil {
+					return err
+				}
+			}
+			resume.Experience = experience
+		}
+		return nil
+	})
+}
+
+// DeleteResume removes a resume with its education, experience, scores and
+// score history, then deletes the uploaded file. It returns
+// gorm.ErrRecordNotFound when there is no such resume.
+func DeleteResume(db *gorm.DB, id string) error {
+	var resume models.Resume
+	if err := db.Select("id", "file_path").Where("id = ?", id).First(&resume).Error; err != nil {
+		return err
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, model := range []interface{}{
+			&models.CandidateScoreHistory{},
+			&models.CandidateScore{},
+			&models.Education{},
+			&models.Experience{},
+		} {
+			if err := tx.Where("resume_id = ?", resume.ID).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Delete(&resume).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	// The rows are gone, so a file that can't be removed is only logged.
+	RemoveResumeFile(resume.FilePath)
+	return nil
+}
+
+// RemoveResumeFile deletes an uploaded resume file. Failures are logged,
+// since the file is no longer referenced by the time it is removed.
+func RemoveResumeFile(path string) {
+	if path == "" {
+		return
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Printf("Failed to remove resume file %s: %v", path, err)
+	}
+}
@@ -0,0 +1,323 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/database"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+	"gorm.io/gorm"
+)
+
+// DefaultScoringProfileName is the global profile used by jobs without one.
+// It is seeded on startup and cannot be deleted.
+const DefaultScoringProfileName = "default"
+
+var (
+	ErrScoringProfileNotFound = errors.New("scoring profile not found")
+	ErrScoringProfileConflict = errors.New("scoring profile name already in use")
+	ErrScoringProfileInUse    = errors.New("scoring profile is the default or attached to jobs")
+	ErrScoringProfileReadOnly = errors.New("global scoring profiles can only be changed by platform admins")
+	ErrInvalidScoringProfile  = errors.New("invalid scoring profile")
+)
+
+// DefaultScoringProfile returns the built-in weights. It is used to seed the
+// database and when a job is scored without a stored profile.
+func DefaultScoringProfile() models.ScoringProfile {
+	return models.ScoringProfile{
+		Name:             DefaultScoringProfileName,
+		Description:      "Balanced weights used when a job has no profile",
+		RequiredWeight:   0.4,
+		NiceToHaveWeight: 0.2,
+		ExperienceWeight: 0.3,
+		EducationWeight:  0.1,
+		AIWeight:         0.4,
+		Version:          1,
+	}
+}
+
+// ValidateScoringProfile checks that every weight is between 0 and 1 and that
+// the heuristic weights sum to 1.
+func ValidateScoringProfile(profile *models.ScoringProfile) error {
+	if strings.TrimSpace(profile.Name) == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidScoringProfile)
+	}
+
+	weights := map[string]float64{
+		"required_weight":     profile.RequiredWeight,
+		"nice_to_have_weight": profile.NiceToHaveWeight,
+		"experience_weight":   profile.ExperienceWeight,
+		"education_weight":    profile.EducationWeight,
+		"ai_weight":           profile.AIWeight,
+	}
+	for name, weight := range weights {
+		if weight < 0 || weight > 1 {
+			return fmt.Errorf("%w: %s must be between 0 and 1", ErrInvalidScoringProfile, name)
+		}
+	}
+
+	sum := profile.RequiredWeight + profile.NiceToHaveWeight + profile.ExperienceWeight + profile.EducationWeight
+	if math.Abs(sum-1) > 1e-6 {
+		return fmt.Errorf("%w: required, nice-to-have, experience and education weights must sum to 1, got %.4g", ErrInvalidScoringProfile, sum)
+	}
+	return nil
+}
+
+// ScoringProfileService stores scoring profiles and resolves the profile a
+// job is scored with. Methods taking a context see the profiles of the
+// organization set with database.WithOrganization plus the global ones.
+type ScoringProfileService struct {
+	db            *gorm.DB
+	organizations *OrganizationService
+}
+
+func NewScoringProfileService(db *gorm.DB) *ScoringProfileService {
+	return &ScoringProfileService{db: db}
+}
+
+// SetOrganizations lets jobs fall back to their organization's default
+// profile before the stored default.
+func (s *ScoringProfileService) SetOrganizations(organizations *OrganizationService) {
+	s.organizations = organizations
+}
+
+// Seed creates the global default profile if it does not exist yet.
+func (s *ScoringProfileService) Seed() error {
+	var count int64
+	if err := s.db.Model(&models.ScoringProfile{}).Where("name = ? AND organization_id IS NULL", DefaultScoringProfileName).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	profile := DefaultScoringProfile()
+	return s.db.Create(&profile).Error
+}
+
+func (s *ScoringProfileService) List(ctx context.Context) ([]models.ScoringProfile, error) {
+	var profiles []models.ScoringProfile
+	if err := s.db.WithContext(ctx).Order("name").Find(&profiles).Error; err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+func (s *ScoringProfileService) Get(ctx context.Context, id string) (*models.ScoringProfile, error) {
+	var profile models.ScoringProfile
+	if err := s.db.WithContext(ctx).Where("id = ?", id).First(&profile).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrScoringProfileNotFound
+		}
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// Create stores a profile in the organization of ctx.
+func (s *ScoringProfileService) Create(ctx context.Context, profile *models.ScoringProfile) error {
+	profile.Name = strings.TrimSpace(profile.Name)
+	profile.Version = 1
+	profile.OrganizationID = nil
+	if err := ValidateScoringProfile(profile); err != nil {
+		return err
+	}
+	if err := s.ensureNameUnused(ctx, "", profile.Name); err != nil {
+		return err
+	}
+	return s.db.WithContext(ctx).Create(profile).Error
+}
+
+// Update replaces the profile's name and weights and bumps its version so
+// scores computed with the old weights can be told apart. Global profiles
+// need platformAdmin.
+func (s *ScoringProfileService) Update(ctx context.Context, id string, input *models.ScoringProfile, platformAdmin bool) (*models.ScoringProfile, error) {
+	profile, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if profile.OrganizationID == nil && !platformAdmin {
+		return nil, ErrScoringProfileReadOnly
+	}
+
+	name := strings.TrimSpace(input.Name)
+	if profile.Name == DefaultScoringProfileName && name != DefaultScoringProfileName {
+		return nil, fmt.Errorf("%w: the default profile cannot be renamed", ErrInvalidScoringProfile)
+	}
+	if err := s.ensureNameUnused(ctx, profile.ID, name); err != nil {
+		return nil, err
+	}
+
+	profile.Name = name
+	profile.Description = input.Description
+	profile.RequiredWeight = input.RequiredWeight
+	profile.NiceToHaveWeight = input.NiceToHaveWeight
+	profile.ExperienceWeight = input.ExperienceWeight
+	profile.EducationWeight = input.EducationWeight
+	profile.AIWeight = input.AIWeight
+	if err := ValidateScoringProfile(profile); err != nil {
+		return nil, err
+	}
+
+	// The profile was looked up in the caller's organization, so it is
+	// written without the scope, which would skip global profiles.
+	profile.Version++
+	if err := s.db.Save(profile).Error; err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// Delete removes a profile that is neither the default nor attached to a
+// job. Global profiles need platformAdmin.
+func (s *ScoringProfileService) Delete(ctx context.Context, id string, platformAdmin bool) error {
+	profile, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if profile.Name == DefaultScoringProfileName && profile.OrganizationID == nil {
+		return ErrScoringProfileInUse
+	}
+	if profile.OrganizationID == nil && !platformAdmin {
+		return ErrScoringProfileReadOnly
+	}
+
+	// Deleted jobs still reference the profile.
+	var jobs int64
+	if err := s.db.Unscoped().Model(&models.JobDescription{}).Where("scoring_profile_id = ?", profile.ID).Count(&jobs).Error; err != nil {
+		return err
+	}
+	if jobs > 0 {
+		return ErrScoringProfileInUse
+	}
+
+	var organizations int64
+	if err := s.db.Model(&models.Organization{}).Where("settings->>'default_scoring_profile_id' = ?", profile.ID).Count(&organizations).Error; err != nil {
+		return err
+	}
+	if organizations > 0 {
+		return ErrScoringProfileInUse
+	}
+
+	return s.db.Delete(profile).Error
+}
+
+// Resolve loads the profile a job should be scored with into
+// job.ScoringProfile: the job's own profile, its organization's default or
+// the stored default. If none exists the matcher falls back to
+// DefaultScoringProfile.
+func (s *ScoringProfileService) Resolve(job *models.JobDescription) error {
+	if job.ScoringProfileID != nil && *job.ScoringProfileID != "" {
+		// Attaching checks the organization, so the lookup is not scoped:
+		// jobs attached to a profile before profiles had organizations
+		// keep it.
+		profile, err := s.Get(context.Background(), *job.ScoringProfileID)
+		if err != nil {
+			return err
+		}
+		job.ScoringProfile = profile
+		return nil
+	}
+
+	profile, err := s.defaultFor(job.OrganizationID)
+	if err != nil {
+		return err
+	}
+	job.ScoringProfile = profile
+	return nil
+}
+
+// ResolveAll fills in the default profile for jobs loaded with
+// Preload("ScoringProfile") that have none of their own. A scoped preload
+// skips profiles of another organization, so those are loaded like in
+// Resolve.
+func (s *ScoringProfileService) ResolveAll(jobs []models.JobDescription) error {
+	fallbacks := make(map[string]*models.ScoringProfile)
+	for i := range jobs {
+		if jobs[i].ScoringProfile != nil {
+			continue
+		}
+		if jobs[i].ScoringProfileID != nil && *jobs[i].ScoringProfileID != "" {
+			if err := s.Resolve(&jobs[i]); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var key string
+		if jobs[i].OrganizationID != nil {
+			key = *jobs[i].OrganizationID
+		}
+		fallback, ok := fallbacks[key]
+		if !ok {
+			var err error
+			fallback, err = s.defaultFor(jobs[i].OrganizationID)
+			if err != nil {
+				return err
+			}
+			fallbacks[key] = fallback
+		}
+		jobs[i].ScoringProfile = fallback
+	}
+	return nil
+}
+
+// defaultFor returns the organization's default profile, or the stored
+// default when the organization has none. It returns nil when neither
+// exists.
+func (s *ScoringProfileService) defaultFor(organizationID *string) (*models.ScoringProfile, error) {
+	if organizationID != nil && s.organizations != nil {
+		settings, err := s.organizations.Settings(*organizationID)
+		if err != nil && !errors.Is(err, ErrOrganizationNotFound) {
+			return nil, err
+		}
+		if id := settings.DefaultScoringProfileID; id != nil {
+			profile, err := s.Get(organizationContext(organizationID), *id)
+			if err == nil {
+				return profile, nil
+			}
+			if !errors.Is(err, ErrScoringProfileNotFound) {
+				return nil, err
+			}
+		}
+	}
+
+	var profile models.ScoringProfile
+	err := s.db.Where("name = ? AND organization_id IS NULL", DefaultScoringProfileName).First(&profile).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// ensureNameUnused checks the name against the profiles visible in ctx, so
+// an organization cannot shadow a global profile.
+func (s *ScoringProfileService) ensureNameUnused(ctx context.Context, exceptID, name string) error {
+	var existing models.ScoringProfile
+	err := s.db.WithContext(ctx).Where("LOWER(name) = LOWER(?)", name).First(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if existing.ID != exceptID {
+		return ErrScoringProfileConflict
+	}
+	return nil
+}
+
+// organizationContext scopes lookups to a job's organization. Every job has
+// one once the organization backfill has run.
+func organizationContext(organizationID *string) context.Context {
+	if organizationID == nil {
+		return context.Background()
+	}
+	return database.WithOrganization(context.Background(), *organizationID)
+}
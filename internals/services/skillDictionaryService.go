@@ -0,0 +1,295 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/taxonomy"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// skillInvalidationChannel is the Redis pub/sub channel used to tell every
+// running instance to reload the skill dictionary.
+const skillInvalidationChannel = "skills:invalidate"
+
+var (
+	ErrSkillNotFound = errors.New("skill not found")
+	ErrSkillConflict = errors.New("skill name or alias already in use")
+)
+
+// SkillDictionaryService keeps the skill taxonomy in Postgres and pushes
+// changes into the in-memory taxonomy used by the parser and matcher.
+type SkillDictionaryService struct {
+	db       *gorm.DB
+	redis    *redis.Client
+	taxonomy *taxonomy.Taxonomy
+}
+
+func NewSkillDictionaryService(db *gorm.DB, redisClient *redis.Client, tax *taxonomy.Taxonomy) *SkillDictionaryService {
+	return &SkillDictionaryService{
+		db:       db,
+		redis:    redisClient,
+		taxonomy: tax,
+	}
+}
+
+// Seed fills an empty dictionary with the built-in vocabulary.
+func (s *SkillDictionaryService) Seed() error {
+	var count int64
+	if err := s.db.Model(&models.Skill{}).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	var skills []models.Skill
+	for _, skill := range taxonomy.Builtin() {
+		skills = append(skills, models.Skill{
+			Name:          skill.Name,
+			Category:      skill.Category,
+			Aliases:       skill.Aliases,
+			CaseSensitive: skill.CaseSensitive,
+			Related:       skill.Related,
+		})
+	}
+
+	return s.db.Create(&skills).Error
+}
+
+// Reload replaces the in-memory taxonomy with the contents of the database.
+func (s *SkillDictionaryService) Reload() error {
+	var rows []models.Skill
+	if err := s.db.Order("name").Find(&rows).Error; err != nil {
+		return err
+	}
+
+	skills := make([]taxonomy.Skill, 0, len(rows))
+	for _, row := range rows {
+		skills = append(skills, taxonomy.Skill{
+			Name:          row.Name,
+			Category:      row.Category,
+			Aliases:       row.Aliases,
+			CaseSensitive: row.CaseSensitive,
+			Deprecated:    row.Deprecated,
+			Related:       row.Related,
+		})
+	}
+
+	s.taxonomy.Replace(skills)
+	return nil
+}
+
+// Watch reloads the taxonomy whenever another instance announces a change.
+// It blocks until ctx is cancelled.
+func (s *SkillDictionaryService) Watch(ctx context.Context) {
+	sub := s.redis.Subscribe(ctx, skillInvalidationChannel)
+	defer sub.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case _, ok := <-sub.Channel():
+			if !ok {
+				return
+			}
+			if err := s.Reload(); err != nil {
+				log.Printf("Failed to reload skill dictionary: %v", err)
+			}
+		}
+	}
+}
+
+func (s *SkillDictionaryService) List(includeDeprecated bool) ([]models.Skill, error) {
+	query := s.db.Order("name")
+	if !includeDeprecated {
+		query = query.Where("deprecated = ?", false)
+	}
+
+	var skills []models.Skill
+	if err := query.Find(&skills).Error; err != nil {
+		return nil, err
+	}
+	return skills, nil
+}
+
+func (s *SkillDictionaryService) Create(skill *models.Skill) error {
+	skill.Name = strings.TrimSpace(skill.Name)
+	skill.Aliases = cleanAliases(skill.Aliases)
+	if err := s.ensureUnused("", append([]string{skill.Name}, skill.Aliases...)); err != nil {
+		return err
+	}
+
+	if err := s.db.Create(skill).Error; err != nil {
+		return err
+	}
+	return s.changed()
+}
+
+func (s *SkillDictionaryService) AddAliases(id string, aliases []string) (*models.Skill, error) {
+	skill, err := s.find(id)
+	if err != nil {
+		return nil, err
+	}
+
+	aliases = cleanAliases(aliases)
+	if err := s.ensureUnused(skill.ID, aliases); err != nil {
+		return nil, err
+	}
+
+	skill.Aliases = cleanAliases(append(skill.Aliases, aliases...))
+	if err := s.db.Save(skill).Error; err != nil {
+		return nil, err
+	}
+	return skill, s.changed()
+}
+
+// Merge folds the source skill into the target: the source name and its
+// aliases become aliases of the target, and the source entry is removed.
+func (s *SkillDictionaryService) Merge(sourceID, targetID string) (*models.Skill, error) {
+	if sourceID == targetID {
+		return nil, ErrSkillConflict
+	}
+
+	source, err := s.find(sourceID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.find(targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	target.Aliases = cleanAliases(append(append(target.Aliases, source.Name), source.Aliases...))
+	target.Related = removeName(cleanAliases(append(target.Related, source.Related...)), target.Name)
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(source).Error; err != nil {
+			return err
+		}
+		return tx.Save(target).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return target, s.changed()
+}
+
+// AddRelated marks skills as related to the given skill so they earn
+// partial credit for each other. Every name must already be in the
+// dictionary.
+func (s *SkillDictionaryService) AddRelated(id string, names []string) (*models.Skill, error) {
+	skill, err := s.find(id)
+	if err != nil {
+		return nil, err
+	}
+
+	names = cleanAliases(names)
+	for _, name := range names {
+		related, ok := s.taxonomy.Lookup(name)
+		if !ok {
+			return nil, ErrSkillNotFound
+		}
+		if strings.EqualFold(related.Name, skill.Name) {
+			return nil, ErrSkillConflict
+		}
+		skill.Related = append(skill.Related, related.Name)
+	}
+
+	skill.Related = cleanAliases(skill.Related)
+	if err := s.db.Save(skill).Error; err != nil {
+		return nil, err
+	}
+	return skill, s.changed()
+}
+
+func (s *SkillDictionaryService) Deprecate(id string) (*models.Skill, error) {
+	skill, err := s.find(id)
+	if err != nil {
+		return nil, err
+	}
+
+	skill.Deprecated = true
+	if err := s.db.Save(skill).Error; err != nil {
+		return nil, err
+	}
+	return skill, s.changed()
+}
+
+func (s *SkillDictionaryService) find(id string) (*models.Skill, error) {
+	var skill models.Skill
+	if err := s.db.Where("id = ?", id).First(&skill).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSkillNotFound
+		}
+		return nil, err
+	}
+	return &skill, nil
+}
+
+// ensureUnused fails if any of the names already identifies a skill other
+// than the one with exceptID.
+func (s *SkillDictionaryService) ensureUnused(exceptID string, names []string) error {
+	var skills []models.Skill
+	if err := s.db.Find(&skills).Error; err != nil {
+		return err
+	}
+
+	used := make(map[string]string)
+	for _, skill := range skills {
+		used[strings.ToLower(skill.Name)] = skill.ID
+		for _, alias := range skill.Aliases {
+			used[strings.ToLower(alias)] = skill.ID
+		}
+	}
+
+	for _, name := range names {
+		if id, ok := used[strings.ToLower(name)]; ok && id != exceptID {
+			return ErrSkillConflict
+		}
+	}
+	return nil
+}
+
+// changed reloads the local taxonomy and notifies the other instances.
+func (s *SkillDictionaryService) changed() error {
+	if err := s.Reload(); err != nil {
+		return err
+	}
+	if s.redis == nil {
+		return nil
+	}
+	return s.redis.Publish(context.Background(), skillInvalidationChannel, "reload").Err()
+}
+
+func cleanAliases(aliases []string) []string {
+	seen := make(map[string]bool)
+	var result []string
+
+	for _, alias := range aliases {
+		alias = strings.TrimSpace(alias)
+		key := strings.ToLower(alias)
+		if alias == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, alias)
+	}
+
+	return result
+}
+
+func removeName(names []string, name string) []string {
+	var result []string
+	for _, n := range names {
+		if !strings.EqualFold(n, name) {
+			result = append(result, n)
+		}
+	}
+	return result
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/taxonomy"
+)
+
+// How a job skill was found on a resume.
+const (
+	SkillMatchExact   = "exact"   // same name
+	SkillMatchAlias   = "alias"   // same skill under an alias or variant spelling
+	SkillMatchTypo    = "typo"    // probably the same skill, one or two typos away
+	SkillMatchRelated = "related" // a related skill, e.g. MySQL for PostgreSQL
+	SkillMatchMissing = "missing"
+)
+
+// Partial credit for a skill that was only matched through a typo
+// correction, and for a related skill.
+const (
+	typoSkillCredit    = 0.75
+	relatedSkillCredit = 0.5
+)
+
+var skillMatchCredit = map[string]float64{
+	SkillMatchExact:   1,
+	SkillMatchAlias:   1,
+	SkillMatchTypo:    typoSkillCredit,
+	SkillMatchRelated: relatedSkillCredit,
+	SkillMatchMissing: 0,
+}
+
+type resolvedSkill struct {
+	written   string
+	canonical string
+	typo      bool // canonical is a typo correction of written
+}
+
+// matchSkills compares the resume's skills with each job skill and returns
+// the share of credit earned along with a per-skill breakdown.
+func matchSkills(resumeSkills, jobSkills []string) (float64, []models.SkillMatch) {
+	if len(jobSkills) == 0 {
+		return 1.0, nil
+	}
+
+	skills := taxonomy.Default()
+	have := resolveSkills(resumeSkills)
+
+	total := 0.0
+	breakdown := make([]models.SkillMatch, 0, len(jobSkills))
+	for _, jobSkill := range jobSkills {
+		match := matchSkill(skills, have, jobSkill)
+		total += match.Credit
+		breakdown = append(breakdown, match)
+	}
+
+	return total / float64(len(jobSkills)), breakdown
+}
+
+func resolveSkills(names []string) []resolvedSkill {
+	skills := taxonomy.Default()
+	resolved := make([]resolvedSkill, 0, len(names))
+	for _, name := range names {
+		resolved = append(resolved, resolveSkill(skills, name))
+	}
+	return resolved
+}
+
+func matchSkill(skills *taxonomy.Taxonomy, have []resolvedSkill, jobSkill string) models.SkillMatch {
+	want := resolveSkill(skills, jobSkill)
+	match := models.SkillMatch{Skill: jobSkill, Kind: SkillMatchMissing}
+
+	for _, candidate := range have {
+		if !strings.EqualFold(candidate.canonical, want.canonical) {
+			continue
+		}
+		kind := SkillMatchAlias
+		switch {
+		case strings.EqualFold(strings.TrimSpace(candidate.written), strings.TrimSpace(jobSkill)):
+			kind = SkillMatchExact
+		case candidate.typo || want.typo:
+			kind = SkillMatchTypo
+		}
+		if skillMatchCredit[kind] > skillMatchCredit[match.Kind] {
+			match.Kind = kind
+			match.MatchedWith = candidate.written
+		}
+		if kind == SkillMatchExact {
+			break
+		}
+	}
+
+	if match.Kind == SkillMatchMissing {
+		for _, candidate := range have {
+			if skills.Related(candidate.canonical, want.canonical) {
+				match.Kind = SkillMatchRelated
+				match.MatchedWith = candidate.written
+				break
+			}
+		}
+	}
+
+	match.Credit = skillMatchCredit[match.Kind]
+	return match
+}
+
+// resolveSkill finds the canonical name for a skill, correcting typos and
+// variant spellings. Unknown skills keep the name as written.
+func resolveSkill(skills *taxonomy.Taxonomy, name string) resolvedSkill {
+	skill, resolution := skills.Resolve(name)
+	if resolution == taxonomy.Unresolved {
+		return resolvedSkill{written: name, canonical: strings.TrimSpace(name)}
+	}
+	return resolvedSkill{written: name, canonical: skill.Name, typo: resolution == taxonomy.ResolvedTypo}
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/amarjeet-choudhary666/ai_resume_screener/internals/models"
+)
+
+func TestMatchSkills(t *testing.T) {
+	resume := []string{"Golang", "React.js", "MSSQL", "Postgres", "Docker"}
+
+	tests := []struct {
+		jobSkill string
+		kind     string
+		credit   float64
+	}{
+		{"Docker", SkillMatchExact, 1},
+		{"Go", SkillMatchAlias, 1},
+		{"React", SkillMatchAlias, 1},
+		{"MySQL", SkillMatchTypo, typoSkillCredit},
+		{"SQL", SkillMatchRelated, relatedSkillCredit},
+		{"Rust", SkillMatchMissing, 0},
+	}
+	for _, tt := range tests {
+		_, breakdown := matchSkills(resume, []string{tt.jobSkill})
+		if len(breakdown) != 1 {
+			t.Fatalf("%s: got %d matches", tt.jobSkill, len(breakdown))
+		}
+		if got := breakdown[0]; got.Kind != tt.kind || got.Credit != tt.credit {
+			t.Errorf("%s: kind %q credit %v, want %q %v", tt.jobSkill, got.Kind, got.Credit, tt.kind, tt.credit)
+		}
+	}
+
+	share, _ := matchSkills(resume, []string{"Docker", "Rust"})
+	if share != 0.5 {
+		t.Errorf("share = %v, want 0.5", share)
+	}
+	if share, _ := matchSkills(resume, nil); share != 1 {
+		t.Errorf("no job skills: share = %v, want 1", share)
+	}
+}
+
+func TestRequiredSkillKnockoutIgnoresTypos(t *testing.T) {
+	rule := models.KnockoutRule{Type: KnockoutRequiredSkill, Value: "MySQL"}
+	if _, ok := evaluateKnockout(rule, &models.Resume{Skills: []string{"MSSQL"}}); ok {
+		t.Error("MSSQL satisfied a MySQL requirement")
+	}
+	if _, ok := evaluateKnockout(rule, &models.Resume{Skills: []string{"mysql"}}); !ok {
+		t.Error("mysql failed a MySQL requirement")
+	}
+}
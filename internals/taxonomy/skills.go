@@ -0,0 +1,83 @@
+package taxonomy
+
+const (
+	CategoryLanguage  = "language"
+	CategoryFramework = "framework"
+	CategoryDatabase  = "database"
+	CategoryCloud     = "cloud"
+	CategoryDevOps    = "devops"
+	CategoryTool      = "tool"
+	CategoryPractice  = "practice"
+	CategoryWeb       = "web"
+)
+
+// builtinSkills is the vocabulary used until an admin-managed dictionary is
+// loaded.
+var builtinSkills = []Skill{
+	// Languages
+	{Name: "Python", Category: CategoryLanguage, Aliases: []string{"python3"}},
+	{Name: "Java", Category: CategoryLanguage, Related: []string{"Kotlin", "Scala"}},
+	{Name: "JavaScript", Category: CategoryLanguage, Aliases: []string{"js", "ecmascript", "es6"}, Related: []string{"TypeScript"}},
+	{Name: "TypeScript", Category: CategoryLanguage},
+	{Name: "Go", Category: CategoryLanguage, Aliases: []string{"golang"}, CaseSensitive: true},
+	{Name: "C++", Category: CategoryLanguage, Aliases: []string{"cpp"}},
+	{Name: "C#", Category: CategoryLanguage, Aliases: []string{"csharp", "c sharp"}},
+	{Name: "PHP", Category: CategoryLanguage},
+	{Name: "Ruby", Category: CategoryLanguage},
+	{Name: "Swift", Category: CategoryLanguage, CaseSensitive: true},
+	{Name: "Kotlin", Category: CategoryLanguage},
+	{Name: "Rust", Category: CategoryLanguage, CaseSensitive: true},
+	{Name: "Scala", Category: CategoryLanguage},
+	{Name: "SQL", Category: CategoryLanguage},
+
+	// Frameworks and runtimes
+	{Name: "React", Category: CategoryFramework, Aliases: []string{"react.js", "reactjs"}, Related: []string{"Angular", "Vue.js"}},
+	{Name: "Angular", Category: CategoryFramework, Aliases: []string{"angularjs", "angular.js"}, Related: []string{"Vue.js"}},
+	{Name: "Vue.js", Category: CategoryFramework, Aliases: []string{"vue", "vuejs"}},
+	{Name: "Node.js", Category: CategoryFramework, Aliases: []string{"nodejs"}, Related: []string{"Express"}},
+	{Name: "Express", Category: CategoryFramework, Aliases: []string{"express.js", "expressjs"}, CaseSensitive: true},
+	{Name: "Django", Category: CategoryFramework, Related: []string{"Flask"}},
+	{Name: "Flask", Category: CategoryFramework},
+	{Name: "Spring", Category: CategoryFramework, Aliases: []string{"spring boot", "springboot", "spring framework"}, CaseSensitive: true},
+	{Name: "Laravel", Category: CategoryFramework},
+	{Name: "Gin", Category: CategoryFramework, Aliases: []string{"gin-gonic"}, CaseSensitive: true},
+
+	// Databases
+	{Name: "MySQL", Category: CategoryDatabase, Related: []string{"PostgreSQL", "SQL"}},
+	{Name: "PostgreSQL", Category: CategoryDatabase, Aliases: []string{"postgres", "psql", "postgre"}, Related: []string{"SQL"}},
+	{Name: "MongoDB", Category: CategoryDatabase, Aliases: []string{"mongo"}},
+	{Name: "Redis", Category: CategoryDatabase},
+
+	// Cloud
+	{Name: "AWS", Category: CategoryCloud, Aliases: []string{"amazon web services"}, Related: []string{"Azure", "GCP"}},
+	{Name: "Azure", Category: CategoryCloud, Aliases: []string{"microsoft azure"}, Related: []string{"GCP"}},
+	{Name: "GCP", Category: CategoryCloud, Aliases: []string{"google cloud", "google cloud platform"}},
+
+	// DevOps and tooling
+	{Name: "Docker", Category: CategoryDevOps, Related: []string{"Kubernetes"}},
+	{Name: "Kubernetes", Category: CategoryDevOps, Aliases: []string{"k8s"}},
+	{Name: "CI/CD", Category: CategoryDevOps, Aliases: []string{"ci cd", "ci-cd", "continuous integration", "continuous delivery", "continuous deployment"}},
+	{Name: "Jenkins", Category: CategoryDevOps, Related: []string{"CI/CD"}},
+	{Name: "Git", Category: CategoryTool},
+	{Name: "GitHub", Category: CategoryTool, Related: []string{"Git", "GitLab"}},
+	{Name: "GitLab", Category: CategoryTool, Related: []string{"Git"}},
+	{Name: "Linux", Category: CategoryTool},
+	{Name: "Windows", Category: CategoryTool, CaseSensitive: true},
+
+	// Web
+	{Name: "HTML", Category: CategoryWeb, Aliases: []string{"html5"}},
+	{Name: "CSS", Category: CategoryWeb, Aliases: []string{"css3"}},
+	{Name: "GraphQL", Category: CategoryWeb},
+	{Name: "REST", Category: CategoryWeb, Aliases: []string{"rest api", "rest apis", "restful", "restful api", "restful apis"}, CaseSensitive: true},
+	{Name: "Microservices", Category: CategoryWeb, Aliases: []string{"microservice", "micro-services"}},
+
+	// Practices
+	{Name: "Agile", Category: CategoryPractice, Related: []string{"Scrum"}},
+	{Name: "Scrum", Category: CategoryPractice},
+}
+
+// Builtin returns a copy of the built-in vocabulary, used to seed the skill
+// dictionary on first start.
+func Builtin() []Skill {
+	return append([]Skill(nil), builtinSkills...)
+}
@@ -0,0 +1,406 @@
+package taxonomy
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Skill is a canonical skill with the alternative spellings it is known by.
+type Skill struct {
+	Name     string   `json:"name"`
+	Category string   `json:"category"`
+	Aliases  []string `json:"aliases"`
+	// CaseSensitive skills share their name with a common English word
+	// ("Go", "Swift", "Express"), so text only matches the canonical
+	// casing. Aliases are still matched case-insensitively.
+	CaseSensitive bool `json:"case_sensitive"`
+	// Deprecated skills are no longer extracted from text but still
+	// canonicalize, so skills already stored on resumes keep matching.
+	Deprecated bool `json:"deprecated"`
+	// Related skills earn partial credit for each other, e.g. MySQL for a
+	// PostgreSQL requirement. The relation is symmetric, so it only needs
+	// to be listed on one side.
+	Related []string `json:"related"`
+}
+
+type term struct {
+	text          string
+	skill         int
+	caseSensitive bool
+}
+
+// Taxonomy indexes a set of skills for canonicalization and text matching.
+// It is safe for concurrent use, and Replace swaps the whole vocabulary at
+// once so readers never see a half-updated index.
+type Taxonomy struct {
+	mu        sync.RWMutex
+	skills    []Skill
+	byKey     map[string]int
+	byCompact map[string]int
+	related   map[int]map[int]bool
+	terms     []term
+}
+
+var defaultTaxonomy = New(builtinSkills)
+
+// Default returns the process-wide taxonomy shared by the parser, the AI
+// fallback and the matcher.
+func Default() *Taxonomy {
+	return defaultTaxonomy
+}
+
+func New(skills []Skill) *Taxonomy {
+	t := &Taxonomy{}
+	t.Replace(skills)
+	return t
+}
+
+// Replace swaps the vocabulary for the given skills.
+func (t *Taxonomy) Replace(skills []Skill) {
+	byKey := make(map[string]int)
+	var terms []term
+
+	for i, skill := range skills {
+		byKey[normalizeKey(skill.Name)] = i
+		for _, alias := range skill.Aliases {
+			byKey[normalizeKey(alias)] = i
+		}
+
+		if skill.Deprecated {
+			continue
+		}
+		terms = append(terms, term{text: skill.Name, skill: i, caseSensitive: skill.CaseSensitive})
+		for _, alias := range skill.Aliases {
+			terms = append(terms, term{text: alias, skill: i})
+		}
+	}
+
+	for i := range terms {
+		if !terms[i].caseSensitive {
+			terms[i].text = normalizeKey(terms[i].text)
+		}
+	}
+
+	byCompact := make(map[string]int)
+	for key, i := range byKey {
+		if compact := compactKey(key); compact != "" {
+			byCompact[compact] = i
+		}
+	}
+
+	related := make(map[int]map[int]bool)
+	link := func(a, b int) {
+		if related[a] == nil {
+			related[a] = make(map[int]bool)
+		}
+		related[a][b] = true
+	}
+	for i, skill := range skills {
+		for _, name := range skill.Related {
+			if j, ok := byKey[normalizeKey(name)]; ok && j != i {
+				link(i, j)
+				link(j, i)
+			}
+		}
+	}
+
+	t.mu.Lock()
+	t.skills = append([]Skill(nil), skills...)
+	t.byKey = byKey
+	t.byCompact = byCompact
+	t.related = related
+	t.terms = terms
+	t.mu.Unlock()
+}
+
+// Skills returns a copy of the current vocabulary.
+func (t *Taxonomy) Skills() []Skill {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return append([]Skill(nil), t.skills...)
+}
+
+// Lookup returns the skill known by the given name or alias.
+func (t *Taxonomy) Lookup(name string) (Skill, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	i, ok := t.byKey[normalizeKey(name)]
+	if !ok {
+		return Skill{}, false
+	}
+	return t.skills[i], true
+}
+
+// Resolution says how Resolve found a skill.
+type Resolution int
+
+const (
+	Unresolved Resolution = iota
+	// ResolvedName is the skill's name or an alias, ignoring case,
+	// spacing and punctuation.
+	ResolvedName
+	// ResolvedTypo is the closest name or alias by edit distance. It is a
+	// guess ("MSSQL" is one edit from "MySQL"), so callers should not treat
+	// it as the same skill.
+	ResolvedTypo
+)
+
+// Resolve finds the skill for a name that may be misspelled or written
+// differently from the dictionary. It tries an exact name or alias first,
+// then the name with punctuation and spaces removed ("React JS" for
+// "reactjs"), then the closest entry by edit distance that starts with the
+// same character. The second result is Unresolved when nothing is close
+// enough.
+func (t *Taxonomy) Resolve(name string) (Skill, Resolution) {
+	if skill, ok := t.Lookup(name); ok {
+		return skill, ResolvedName
+	}
+
+	compact := compactKey(name)
+	maxDistance := maxEditDistance(compact)
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if i, ok := t.byCompact[compact]; ok {
+		return t.skills[i], ResolvedName
+	}
+	if maxDistance == 0 {
+		return Skill{}, Unresolved
+	}
+
+	first, _ := utf8.DecodeRuneInString(compact)
+	best, bestDistance, tie := -1, maxDistance+1, false
+	for key, i := range t.byCompact {
+		// A typo in the first character is rare, and allowing one turns
+		// unrelated names like "Bython" and "Python" into the same skill.
+		if r, _ := utf8.DecodeRuneInString(key); r != first {
+			continue
+		}
+		d := editDistance(compact, key, maxDistance)
+		switch {
+		case d < bestDistance:
+			best, bestDistance, tie = i, d, false
+		case d == bestDistance && i != best:
+			tie = true
+		}
+	}
+	if best < 0 || tie {
+		return Skill{}, Unresolved
+	}
+	return t.skills[best], ResolvedTypo
+}
+
+// Related reports whether two skills are listed as related to each other.
+func (t *Taxonomy) Related(a, b string) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	i, ok := t.byKey[normalizeKey(a)]
+	if !ok {
+		return false
+	}
+	j, ok := t.byKey[normalizeKey(b)]
+	if !ok {
+		return false
+	}
+	return t.related[i][j]
+}
+
+// Canonicalize maps a skill name or alias to its canonical name. Unknown
+// names are returned trimmed and the second result is false.
+func (t *Taxonomy) Canonicalize(name string) (string, bool) {
+	if skill, ok := t.Lookup(name); ok {
+		return skill.Name, true
+	}
+	return strings.TrimSpace(name), false
+}
+
+// Category returns the category of a skill, or "" if it is unknown.
+func (t *Taxonomy) Category(name string) string {
+	skill, _ := t.Lookup(name)
+	return skill.Category
+}
+
+// Normalize canonicalizes a list of skill names and drops duplicates and
+// blanks, keeping first-seen order. Unknown skills are kept as written.
+func (t *Taxonomy) Normalize(names []string) []string {
+	seen := make(map[string]bool)
+	var result []string
+
+	for _, name := range names {
+		canonical, _ := t.Canonicalize(name)
+		key := normalizeKey(canonical)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, canonical)
+	}
+
+	return result
+}
+
+// Extract returns the canonical names of every skill mentioned in text, in
+// the order they first appear. Matches must sit on token boundaries, so "go"
+// does not match "Google" and "java" does not match "JavaScript".
+func (t *Taxonomy) Extract(text string) []string {
+	original := strings.Join(strings.Fields(text), " ")
+	lower := strings.ToLower(original)
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	firstSeen := make(map[int]int)
+	for _, tm := range t.terms {
+		haystack := lower
+		if tm.caseSensitive {
+			haystack = original
+		}
+
+		pos := findToken(haystack, tm.text)
+		if pos < 0 {
+			continue
+		}
+		if prev, ok := firstSeen[tm.skill]; !ok || pos < prev {
+			firstSeen[tm.skill] = pos
+		}
+	}
+
+	ordered := make([]int, 0, len(firstSeen))
+	for i := range firstSeen {
+		ordered = append(ordered, i)
+	}
+	sort.Slice(ordered, func(a, b int) bool {
+		return firstSeen[ordered[a]] < firstSeen[ordered[b]]
+	})
+
+	names := make([]string, len(ordered))
+	for i, idx := range ordered {
+		names[i] = t.skills[idx].Name
+	}
+	return names
+}
+
+// findToken returns the index of the first occurrence of needle in haystack
+// that is not glued to surrounding word characters, or -1.
+func findToken(haystack, needle string) int {
+	if needle == "" {
+		return -1
+	}
+
+	offset := 0
+	for {
+		i := strings.Index(haystack[offset:], needle)
+		if i < 0 {
+			return -1
+		}
+		start := offset + i
+		end := start + len(needle)
+		if isBoundaryBefore(haystack[:start]) && isBoundaryAfter(haystack[end:]) {
+			return start
+		}
+		offset = start + 1
+	}
+}
+
+// isBoundaryBefore reports whether a token may start right after prefix. A
+// dot only separates tokens when it isn't joining two words, so "js" does not
+// match inside "node.js".
+func isBoundaryBefore(prefix string) bool {
+	r, size := utf8.DecodeLastRuneInString(prefix)
+	if size == 0 {
+		return true
+	}
+	if r == '.' {
+		r, size = utf8.DecodeLastRuneInString(prefix[:len(prefix)-size])
+		return size == 0 || !isWordChar(r)
+	}
+	return !isWordChar(r)
+}
+
+// isBoundaryAfter reports whether a token may end right before suffix. "Go."
+// at the end of a sentence still matches "Go", but "go.mod" does not.
+func isBoundaryAfter(suffix string) bool {
+	r, size := utf8.DecodeRuneInString(suffix)
+	if size == 0 {
+		return true
+	}
+	if r == '.' {
+		r, size = utf8.DecodeRuneInString(suffix[size:])
+		return size == 0 || !isWordChar(r)
+	}
+	return !isWordChar(r)
+}
+
+func isWordChar(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '+' || r == '#' || r == '_'
+}
+
+func normalizeKey(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// compactKey lowercases a name and drops everything but letters, digits and
+// the characters that distinguish languages ("c++", "c#").
+func compactKey(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '+' || r == '#' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// maxEditDistance is how many typos are tolerated for a compact name. Short
+// names get none, since "go" and "c" are one edit apart from many things.
+func maxEditDistance(compact string) int {
+	switch n := utf8.RuneCountInString(compact); {
+	case n < 5:
+		return 0
+	case n < 9:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// editDistance is the Levenshtein distance between a and b, or limit+1 once
+// it is known to exceed limit.
+func editDistance(a, b string, limit int) int {
+	ra, rb := []rune(a), []rune(b)
+	if diff := len(ra) - len(rb); diff > limit || -diff > limit {
+		return limit + 1
+	}
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			rowMin = min(rowMin, curr[j])
+		}
+		if rowMin > limit {
+			return limit + 1
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
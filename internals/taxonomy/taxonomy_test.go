@@ -0,0 +1,75 @@
+package taxonomy
+
+import "testing"
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name       string
+		want       string
+		resolution Resolution
+	}{
+		{"PostgreSQL", "PostgreSQL", ResolvedName},
+		{"postgres", "PostgreSQL", ResolvedName},
+		{"React JS", "React", ResolvedName},
+		{"K8S", "Kubernetes", ResolvedName},
+		{"Postgress", "PostgreSQL", ResolvedTypo},
+		{"Kubernets", "Kubernetes", ResolvedTypo},
+		{"MSSQL", "MySQL", ResolvedTypo},
+		{"Flash", "Flask", ResolvedTypo},
+		{"Shift", "Swift", ResolvedTypo},
+		{"Bython", "", Unresolved},
+		{"Gp", "", Unresolved},
+		{"Underwater basket weaving", "", Unresolved},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			skill, resolution := Default().Resolve(tt.name)
+			if resolution != tt.resolution || skill.Name != tt.want {
+				t.Errorf("Resolve(%q) = %q, %v; want %q, %v", tt.name, skill.Name, resolution, tt.want, tt.resolution)
+			}
+		})
+	}
+}
+
+func TestResolveTie(t *testing.T) {
+	skills := New([]Skill{{Name: "Abcde"}, {Name: "Abcdf"}})
+	if _, resolution := skills.Resolve("Abcdx"); resolution != Unresolved {
+		t.Errorf("a tie resolved as %v", resolution)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		limit int
+		want  int
+	}{
+		{"mysql", "mysql", 1, 0},
+		{"mssql", "mysql", 1, 1},
+		{"flash", "flask", 1, 1},
+		{"shift", "swift", 1, 1},
+		{"django", "djnago", 2, 2},
+		{"postgress", "postgres", 2, 1},
+		{"kubernetes", "k8s", 2, 3},
+		{"python", "java", 1, 2},
+		{"", "go", 2, 2},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b, tt.limit); got != tt.want {
+			t.Errorf("editDistance(%q, %q, %d) = %d, want %d", tt.a, tt.b, tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestExtractTokenBoundaries(t *testing.T) {
+	got := Default().Extract("Built services in Go and JavaScript at Google; see go.mod")
+	want := map[string]bool{"Go": true, "JavaScript": true}
+	if len(got) != len(want) {
+		t.Fatalf("Extract = %v", got)
+	}
+	for _, name := range got {
+		if !want[name] {
+			t.Errorf("unexpected skill %q in %v", name, got)
+		}
+	}
+}